@@ -0,0 +1,197 @@
+package build
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/container-compose/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	files      []string
+	profiles   []string
+	noCache    bool
+	pull       bool
+	buildArgs  []string
+	parallel   int
+	progress   string
+	cpus       int
+	memory     string
+	memorySize entities.MemorySize // the parsed --memory value
+	cmd        = &cobra.Command{
+		Use:   "build [SERVICE...]",
+		Short: "Build the images of services which have a build configuration",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch progress {
+			case "", "auto", "plain", "tty", "quiet":
+			default:
+				return fmt.Errorf("invalid --progress value %q, must be one of: plain, tty, quiet", progress)
+			}
+			if parallel < 1 {
+				return fmt.Errorf("invalid --parallel value %d, must be at least 1", parallel)
+			}
+			if cmd.Flags().Changed("cpus") && cpus < 1 {
+				return fmt.Errorf("invalid --cpus value %d, must be at least 1", cpus)
+			}
+			memorySize = 0
+			if memory != "" {
+				size, err := entities.ParseMemorySize(memory)
+				if err != nil {
+					return fmt.Errorf("invalid --memory value: %w", err)
+				}
+				memorySize = size
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, logger := logger.New(ctx, os.Stdout, slog.LevelDebug)
+			logger.InfoContext(ctx, "building images", "files", files)
+
+			overrides := make(map[string]string)
+			for _, arg := range buildArgs {
+				key, value, found := strings.Cut(arg, "=")
+				if !found {
+					value = os.Getenv(key)
+				}
+				overrides[key] = value
+			}
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args...)
+
+			selected, err := config.Select(args...)
+			if err != nil {
+				return err
+			}
+
+			var services []*entities.Service
+			for _, service := range selected {
+				if service.Build == nil {
+					if len(args) > 0 {
+						logger.InfoContext(ctx, "service has no build configuration", "service", service.ServiceName)
+					}
+					continue
+				}
+				services = append(services, service)
+			}
+
+			// with more than one build running, terminal progress output would be garbled
+			concurrent := parallel > 1 && len(services) > 1
+			if concurrent && (progress == "" || progress == "auto" || progress == "tty") {
+				progress = "plain"
+			}
+
+			mu := &sync.Mutex{}
+			wg := sync.WaitGroup{}
+			slots := make(chan struct{}, parallel)
+			errs := make([]error, len(services))
+
+			for i, service := range services {
+				wg.Add(1)
+				go func(i int, service *entities.Service) {
+					defer wg.Done()
+					slots <- struct{}{}
+					defer func() { <-slots }()
+
+					build, err := service.BuildCommand(ctx)
+					if err != nil {
+						errs[i] = err
+						return
+					}
+
+					if len(overrides) > 0 {
+						args := make(map[string]string)
+						for k, v := range build.BuildArgs {
+							args[k] = v
+						}
+						for k, v := range overrides {
+							args[k] = v
+						}
+						build.SetBuildArgs(args)
+					}
+					if noCache {
+						build.SetNoCache(true)
+					}
+					if pull {
+						build.SetPull(true)
+					}
+					if cpus > 0 {
+						build.SetCPUs(cpus)
+					}
+					if memorySize > 0 {
+						build.SetMemory(memorySize.Argument())
+					}
+
+					switch progress {
+					case "":
+					case "quiet":
+						build.SetQuiet(true)
+					default:
+						build.SetProgress(progress)
+					}
+
+					// stream the output, prefixed with the service when builds run at once
+					var out io.Writer = os.Stdout
+					if concurrent {
+						writer := output.NewLineWriter(os.Stdout, mu, service.ServiceName+" | ")
+						defer writer.Flush()
+						out = writer
+					}
+					if progress != "quiet" {
+						build.SetOutput(out)
+					}
+
+					logger.InfoContext(ctx, "building service", "service", service.ServiceName, "tag", build.Tag)
+					errs[i] = build.Exec(ctx)
+				}(i, service)
+			}
+			wg.Wait()
+
+			failed := 0
+			for i, err := range errs {
+				if err != nil {
+					logger.ErrorContext(ctx, "failed to build service", "service", services[i].ServiceName, "error", err)
+					failed++
+					continue
+				}
+				logger.InfoContext(ctx, "built service", "service", services[i].ServiceName)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to build %d service(s)", failed)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "do not use the cache when building")
+	cmd.Flags().BoolVar(&pull, "pull", false, "always pull newer versions of base images")
+	cmd.Flags().StringArrayVar(&buildArgs, "build-arg", nil, "set a build argument, KEY=VAL overrides the compose file")
+	cmd.Flags().IntVar(&parallel, "parallel", 4, "maximum number of images to build at once")
+	cmd.Flags().IntVar(&cpus, "cpus", 0, "number of CPUs to build with, overrides the compose file")
+	cmd.Flags().StringVar(&memory, "memory", "", "memory to build with, e.g. 4g, overrides the compose file")
+	cmd.Flags().StringVar(&progress, "progress", "", "progress output, one of: plain, tty, quiet")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	files    []string
+	profiles []string
+	format   string
+	services bool
+	images   bool
+	hash     string
+	cmd      = &cobra.Command{
+		Use:   "config",
+		Short: "Print the compose file as it is understood, with every default filled in",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if format != "yaml" && format != "json" {
+				return fmt.Errorf("invalid --format value %q, must be one of: yaml, json", format)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, _ = logger.New(ctx, os.Stderr, slog.LevelInfo)
+			out := cmd.OutOrStdout()
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles))
+
+			ordered, err := config.Order()
+			if err != nil {
+				return err
+			}
+
+			// the hash is of the configuration as written, so it has to be taken first
+			if hash != "" {
+				names := strings.Split(hash, ",")
+				if hash == "*" {
+					names = nil
+				}
+				selected, err := config.Select(names...)
+				if err != nil {
+					return err
+				}
+				for _, service := range selected {
+					sum, err := service.Hash()
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(out, service.ServiceName, sum)
+				}
+				return nil
+			}
+
+			if err := config.Normalize(ctx); err != nil {
+				return err
+			}
+
+			switch {
+			case services:
+				for _, service := range ordered {
+					fmt.Fprintln(out, service.ServiceName)
+				}
+				return nil
+			case images:
+				var references []string
+				for _, service := range ordered {
+					if service.Image != "" && !slices.Contains(references, service.Image) {
+						references = append(references, service.Image)
+					}
+				}
+				for _, reference := range references {
+					fmt.Fprintln(out, reference)
+				}
+				return nil
+			}
+
+			if format == "json" {
+				// go through a generic value so the output uses the YAML field names
+				data, err := yaml.Marshal(config)
+				if err != nil {
+					return err
+				}
+				var value map[string]interface{}
+				if err := yaml.Unmarshal(data, &value); err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				encoder.SetEscapeHTML(false)
+				return encoder.Encode(value)
+			}
+
+			encoder := yaml.NewEncoder(out)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(config); err != nil {
+				return err
+			}
+			return encoder.Close()
+		},
+	}
+)
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().StringVar(&format, "format", "yaml", "output format, one of: yaml, json")
+	cmd.Flags().BoolVar(&services, "services", false, "only print the service names")
+	cmd.Flags().BoolVar(&images, "images", false, "only print the image names")
+	cmd.Flags().StringVar(&hash, "hash", "", `print the configuration hash of the services, a comma separated list or "*" for every service`)
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
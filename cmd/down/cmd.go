@@ -0,0 +1,261 @@
+package down
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/container-compose/cli/internal/commands"
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// failure records a resource which could not be removed.
+type failure struct {
+	kind string
+	name string
+	err  error
+}
+
+var (
+	files         []string
+	profiles      []string
+	timeout       int
+	rmi           string
+	volumes       bool
+	removeOrphans bool
+	cmd           = &cobra.Command{
+		Use:   "down",
+		Short: "Stop and remove containers and networks, and optionally images and volumes",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if rmi != "" && rmi != "local" && rmi != "all" {
+				return fmt.Errorf("invalid --rmi value %q, must be one of: local, all", rmi)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, logger := logger.New(ctx, os.Stdout, slog.LevelDebug)
+			logger.InfoContext(ctx, "removing containers", "files", files)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles))
+
+			// dependents have to be removed before their dependencies
+			services, err := config.Order()
+			if err != nil {
+				return err
+			}
+			slices.Reverse(services)
+
+			// keep going after a failure so that as much as possible is removed
+			var failures []failure
+			removed := 0
+
+			for _, service := range services {
+				exists, err := service.Exists(ctx)
+				if err != nil {
+					failures = append(failures, failure{"container", service.ServiceName, err})
+					continue
+				}
+				if !exists {
+					continue
+				}
+
+				isRunning, err := service.IsRunning(ctx)
+				if err != nil {
+					failures = append(failures, failure{"container", service.Name, err})
+					continue
+				}
+
+				if err := removeContainer(ctx, service.Name, isRunning); err != nil {
+					failures = append(failures, failure{"container", service.Name, err})
+					continue
+				}
+				logger.InfoContext(ctx, "removed container", "service", service.ServiceName, "name", service.Name)
+				removed++
+			}
+
+			// remove containers labelled with the project whose service is no longer defined
+			if removeOrphans {
+				results, err := config.Containers(ctx, true)
+				if err != nil {
+					failures = append(failures, failure{"orphans", config.Name, err})
+				}
+
+				for _, result := range results {
+					labels := result.Configuration.Labels
+					// one-off containers are always left behind by run, so they count as orphans
+					defined := config.IsDefined(labels[entities.LabelService])
+					if defined && labels[entities.LabelOneOff] != "true" {
+						continue
+					}
+
+					id := result.Configuration.ID
+					if err := removeContainer(ctx, id, result.Status == "running"); err != nil {
+						failures = append(failures, failure{"container", id, err})
+						continue
+					}
+					logger.InfoContext(ctx, "removed orphan container", "service", labels[entities.LabelService], "name", id)
+					removed++
+				}
+			}
+
+			// remove the networks once nothing is attached to them
+			networkKeys := make([]string, 0, len(config.Networks))
+			for key := range config.Networks {
+				networkKeys = append(networkKeys, key)
+			}
+			sort.Strings(networkKeys)
+
+			for _, key := range networkKeys {
+				network := config.Networks[key]
+				if network.IsExternal() {
+					continue
+				}
+
+				exists, err := network.Exists(ctx)
+				if err != nil {
+					failures = append(failures, failure{"network", network.Name, err})
+					continue
+				}
+				if !exists {
+					continue
+				}
+
+				cmd, err := network.DeleteCommand(ctx)
+				if err == nil {
+					err = cmd.Exec(ctx)
+				}
+				if err != nil {
+					failures = append(failures, failure{"network", network.Name, err})
+					continue
+				}
+				logger.InfoContext(ctx, "removed network", "network", network.Name)
+				removed++
+			}
+
+			// remove the images, local only removes the images which were built for a service
+			if rmi != "" {
+				var images []string
+				for _, service := range services {
+					if rmi == "local" && service.Image != "" {
+						continue
+					}
+					reference, err := service.ImageReference(ctx)
+					if err != nil {
+						failures = append(failures, failure{"image", service.ServiceName, err})
+						continue
+					}
+					if !slices.Contains(images, reference) {
+						images = append(images, reference)
+					}
+				}
+
+				for _, image := range images {
+					cmd, err := commands.ImageDelete(image)
+					if err == nil {
+						err = cmd.Exec(ctx)
+					}
+					if err != nil {
+						failures = append(failures, failure{"image", image, err})
+						continue
+					}
+					logger.InfoContext(ctx, "removed image", "image", image)
+					removed++
+				}
+			}
+
+			// remove the declared volumes, external volumes are not the project's to remove
+			if volumes {
+				volumeKeys := make([]string, 0, len(config.Volumes))
+				for key := range config.Volumes {
+					volumeKeys = append(volumeKeys, key)
+				}
+				sort.Strings(volumeKeys)
+
+				for _, key := range volumeKeys {
+					volume := config.Volumes[key]
+					if volume.IsExternal() {
+						continue
+					}
+
+					exists, err := volume.Exists(ctx)
+					if err != nil {
+						failures = append(failures, failure{"volume", volume.Name, err})
+						continue
+					}
+					if !exists {
+						continue
+					}
+
+					cmd, err := volume.DeleteCommand(ctx)
+					if err == nil {
+						err = cmd.Exec(ctx)
+					}
+					if err != nil {
+						failures = append(failures, failure{"volume", volume.Name, err})
+						continue
+					}
+					logger.InfoContext(ctx, "removed volume", "volume", volume.Name)
+					removed++
+				}
+			}
+
+			// summarise what happened
+			for _, f := range failures {
+				logger.ErrorContext(ctx, "failed to remove "+f.kind, "name", f.name, "error", f.err)
+			}
+			logger.InfoContext(ctx, "teardown complete", "removed", removed, "failed", len(failures))
+
+			if len(failures) > 0 {
+				return fmt.Errorf("failed to remove %d resource(s)", len(failures))
+			}
+
+			return nil
+		},
+	}
+)
+
+// removeContainer stops the container when it is running and then deletes it.
+func removeContainer(ctx context.Context, id string, isRunning bool) error {
+	if isRunning {
+		stop, err := commands.Stop(id)
+		if err != nil {
+			return err
+		}
+		if err := stop.SetTimeout(timeout).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	cmd, err := commands.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	return cmd.Exec(ctx)
+}
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 10, "seconds to wait for a service to stop before killing it")
+	cmd.Flags().StringVar(&rmi, "rmi", "", `remove images used by services, "local" removes only images built without a custom tag, "all" removes every image`)
+	cmd.Flags().BoolVarP(&volumes, "volumes", "v", false, "remove the volumes declared in the compose file")
+	cmd.Flags().BoolVar(&removeOrphans, "remove-orphans", false, "remove containers of services not defined in the compose file")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
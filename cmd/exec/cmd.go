@@ -0,0 +1,101 @@
+package exec
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/container-compose/cli/internal/commands"
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/container-compose/cli/internal/problems"
+	"github.com/spf13/cobra"
+)
+
+var (
+	files       []string
+	profiles    []string
+	interactive bool
+	tty         bool
+	environment []string
+	workdir     string
+	user        string
+	cmd         = &cobra.Command{
+		Use:   "exec SERVICE COMMAND [ARG...]",
+		Short: "Run a command in a running service",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, _ = logger.New(ctx, os.Stderr, slog.LevelInfo)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args[0])
+
+			services, err := config.Select(args[0])
+			if err != nil {
+				return err
+			}
+			service := services[0]
+
+			// find the running container of the service, leaving out the one-off containers of run
+			containers, err := config.Containers(ctx, false)
+			if err != nil {
+				return err
+			}
+			id := ""
+			for _, container := range containers {
+				labels := container.Configuration.Labels
+				if labels[entities.LabelService] == service.ServiceName && labels[entities.LabelOneOff] != "true" {
+					id = container.Configuration.ID
+					break
+				}
+			}
+			if id == "" {
+				return fmt.Errorf("service %q is not running", service.ServiceName)
+			}
+
+			exec, err := commands.Exec(id, args[1:])
+			if err != nil {
+				return err
+			}
+			exec.SetInteractive(interactive).
+				SetTTY(tty).
+				SetEnvironment(environment).
+				SetWorkingDir(workdir).
+				SetUser(user)
+
+			code, err := exec.Exec(ctx)
+			if err != nil {
+				return err
+			}
+			if code != 0 {
+				return problems.ExitError{Code: code}
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "keep stdin open")
+	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "allocate a pseudo terminal")
+	cmd.Flags().StringArrayVarP(&environment, "env", "e", nil, "set an environment variable, KEY=VAL")
+	cmd.Flags().StringVarP(&workdir, "workdir", "w", "", "the directory to run the command in")
+	cmd.Flags().StringVarP(&user, "user", "u", "", "the user to run the command as")
+
+	// everything after the service name belongs to the command
+	cmd.Flags().SetInterspersed(false)
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
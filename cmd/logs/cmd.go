@@ -0,0 +1,162 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/container-compose/cli/internal/commands"
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/container-compose/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// colors are the ANSI colors cycled through for the service prefixes
+var colors = []string{"36", "33", "32", "35", "34", "91", "96", "93", "92", "95", "94"}
+
+var (
+	files      []string
+	profiles   []string
+	follow     bool
+	tail       int
+	since      string
+	timestamps bool
+	noColor    bool
+	cmd        = &cobra.Command{
+		Use:   "logs [SERVICE...]",
+		Short: "Show the output of the services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer cancel()
+			ctx, logger := logger.New(ctx, os.Stderr, slog.LevelInfo)
+
+			// the container engine does not record when a line was written, so lines are dated
+			// when they are received
+			start, err := parseSince(since, time.Now())
+			if err != nil {
+				return err
+			}
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args...)
+
+			services, err := config.Select(args...)
+			if err != nil {
+				return err
+			}
+
+			containers, err := config.Containers(ctx, true)
+			if err != nil {
+				return err
+			}
+
+			// pair each selected service with its containers, leaving out the one-off containers of run
+			type source struct {
+				service string
+				id      string
+				color   string
+			}
+			var sources []source
+			width := 0
+			for i, service := range services {
+				for _, container := range containers {
+					labels := container.Configuration.Labels
+					if labels[entities.LabelService] != service.ServiceName || labels[entities.LabelOneOff] == "true" {
+						continue
+					}
+					sources = append(sources, source{service.ServiceName, container.Configuration.ID, colors[i%len(colors)]})
+					width = max(width, len(service.ServiceName))
+				}
+			}
+
+			// stream every container at once, writing whole lines to the shared output
+			mu := &sync.Mutex{}
+			wg := sync.WaitGroup{}
+			errs := make([]error, len(sources))
+			for i, src := range sources {
+				prefix := fmt.Sprintf("%-*s | ", width, src.service)
+				if !noColor {
+					prefix = fmt.Sprintf("\033[%sm%s\033[0m", src.color, prefix)
+				}
+				writer := output.NewLineWriter(cmd.OutOrStdout(), mu, prefix).SetTimestamps(timestamps).SetSince(start)
+
+				wg.Add(1)
+				go func(i int, id string) {
+					defer wg.Done()
+					errs[i] = stream(ctx, id, writer)
+				}(i, src.id)
+			}
+			wg.Wait()
+
+			failed := 0
+			for i, err := range errs {
+				if err != nil {
+					logger.ErrorContext(ctx, "failed to read logs", "service", sources[i].service, "name", sources[i].id, "error", err)
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to read logs of %d container(s)", failed)
+			}
+
+			return nil
+		},
+	}
+)
+
+// stream copies the logs of a single container to the writer.
+func stream(ctx context.Context, id string, writer *output.LineWriter) error {
+	cmd, err := commands.Logs(id)
+	if err != nil {
+		return err
+	}
+	cmd.SetFollow(follow).SetTail(tail)
+
+	err = cmd.Exec(ctx, writer)
+	if flushErr := writer.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// parseSince parses the --since value, which is either a duration relative to now such as
+// 10m, or a timestamp.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q, must be a duration such as 10m or a timestamp", value)
+}
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().BoolVar(&follow, "follow", false, "keep streaming new output")
+	cmd.Flags().IntVarP(&tail, "tail", "n", -1, "number of lines to show from the end of each log, -1 shows every line")
+	cmd.Flags().StringVar(&since, "since", "", "only show lines received since a timestamp or a duration such as 10m")
+	cmd.Flags().BoolVarP(&timestamps, "timestamps", "t", false, "prefix each line with the time it was received")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "do not color the service prefixes")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
@@ -0,0 +1,211 @@
+package ps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/container-compose/cli/internal/commands"
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// healthTimeout is how long ps waits for the healthchecks, the health of a container whose
+// probes have not finished by then is unknown
+const healthTimeout = 5 * time.Second
+
+// row is a single container in the listing.
+type row struct {
+	Service string   `json:"service"`
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Status  string   `json:"status"`
+	Health  string   `json:"health,omitempty"`
+	Ports   []string `json:"ports"`
+	Uptime  string   `json:"uptime"`
+}
+
+var (
+	files    []string
+	profiles []string
+	all      bool
+	oneOff   bool
+	services bool
+	quiet    bool
+	format   string
+	cmd      = &cobra.Command{
+		Use:   "ps [SERVICE...]",
+		Short: "List the project's containers",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("invalid --format value %q, must be one of: table, json", format)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, _ = logger.New(ctx, os.Stderr, slog.LevelInfo)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args...)
+
+			// make sure the named services exist
+			if _, err := config.Select(args...); err != nil {
+				return err
+			}
+
+			containers, err := config.Containers(ctx, all)
+			if err != nil {
+				return err
+			}
+
+			rows := make([]row, 0, len(containers))
+			listed := make([]commands.InspectResult, 0, len(containers))
+			for _, container := range containers {
+				service := container.Configuration.Labels[entities.LabelService]
+				if len(args) > 0 && !slices.Contains(args, service) {
+					continue
+				}
+				if !oneOff && container.Configuration.Labels[entities.LabelOneOff] == "true" {
+					continue
+				}
+				rows = append(rows, newRow(service, container))
+				listed = append(listed, container)
+			}
+
+			// the healthchecks are probed now, as nothing keeps a record of earlier probes.
+			// Every container is probed at once and for no longer than healthTimeout.
+			if !quiet && !services {
+				probeCtx, cancel := context.WithTimeout(ctx, healthTimeout)
+				defer cancel()
+
+				wg := sync.WaitGroup{}
+				for i, container := range listed {
+					defined, ok := config.Services[rows[i].Service]
+					if !ok || container.Status != "running" {
+						continue
+					}
+					started := time.Now()
+					if container.StartedDate != nil {
+						started = container.StartedDate.Time
+					}
+
+					wg.Add(1)
+					go func(i int, id string) {
+						defer wg.Done()
+						rows[i].Health = defined.HealthStatus(probeCtx, id, started)
+					}(i, container.Configuration.ID)
+				}
+				wg.Wait()
+			}
+			sort.Slice(rows, func(i, j int) bool {
+				if rows[i].Service != rows[j].Service {
+					return rows[i].Service < rows[j].Service
+				}
+				return rows[i].Name < rows[j].Name
+			})
+
+			out := cmd.OutOrStdout()
+
+			switch {
+			case quiet:
+				for _, r := range rows {
+					fmt.Fprintln(out, r.Name)
+				}
+			case services:
+				var names []string
+				for _, r := range rows {
+					if !slices.Contains(names, r.Service) {
+						names = append(names, r.Service)
+					}
+				}
+				for _, name := range names {
+					fmt.Fprintln(out, name)
+				}
+			case format == "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				encoder.SetEscapeHTML(false)
+				return encoder.Encode(rows)
+			default:
+				w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+				fmt.Fprintln(w, "SERVICE\tNAME\tIMAGE\tSTATUS\tPORTS\tUPTIME")
+				for _, r := range rows {
+					status := r.Status
+					if r.Health != "" {
+						status = fmt.Sprintf("%s (%s)", r.Status, r.Health)
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Service, r.Name, r.Image, status, strings.Join(r.Ports, ", "), r.Uptime)
+				}
+				return w.Flush()
+			}
+
+			return nil
+		},
+	}
+)
+
+// newRow converts a container into a row of the listing.
+func newRow(service string, container commands.InspectResult) row {
+	ports := make([]string, 0, len(container.Configuration.PublishedPorts))
+	for _, port := range container.Configuration.PublishedPorts {
+		ports = append(ports, port.String())
+	}
+
+	uptime := ""
+	if container.Status == "running" && container.StartedDate != nil {
+		uptime = formatUptime(time.Since(container.StartedDate.Time))
+	}
+
+	return row{
+		Service: service,
+		Name:    container.Configuration.ID,
+		Image:   container.Configuration.Image.Reference,
+		Status:  container.Status,
+		Ports:   ports,
+		Uptime:  uptime,
+	}
+}
+
+// formatUptime formats the duration using its largest unit, e.g. 45s, 12m, 3h or 2d.
+func formatUptime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "show stopped containers as well as running ones")
+	cmd.Flags().BoolVar(&oneOff, "one-off", false, "show the one-off containers created by run as well")
+	cmd.Flags().BoolVar(&services, "services", false, "only print the service names")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "only print the container names")
+	cmd.Flags().StringVar(&format, "format", "table", "output format, one of: table, json")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
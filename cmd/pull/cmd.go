@@ -0,0 +1,134 @@
+package pull
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/container-compose/cli/internal/commands"
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/container-compose/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// image is an image to pull and the services which use it.
+type image struct {
+	reference string
+	services  []string
+	buildable bool
+}
+
+var (
+	files              []string
+	profiles           []string
+	ignorePullFailures bool
+	quiet              bool
+	cmd                = &cobra.Command{
+		Use:   "pull [SERVICE...]",
+		Short: "Pull the images of services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			level := slog.LevelDebug
+			if quiet {
+				level = slog.LevelWarn
+			}
+			ctx, logger := logger.New(ctx, os.Stdout, level)
+			logger.InfoContext(ctx, "pulling images", "files", files)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args...)
+
+			services, err := config.Select(args...)
+			if err != nil {
+				return err
+			}
+
+			// collect the images, services which are only built have nothing to pull
+			var images []*image
+			byReference := make(map[string]*image)
+			for _, service := range services {
+				if service.Image == "" {
+					continue
+				}
+				img, ok := byReference[service.Image]
+				if !ok {
+					img = &image{reference: service.Image}
+					byReference[service.Image] = img
+					images = append(images, img)
+				}
+				img.services = append(img.services, service.ServiceName)
+				img.buildable = img.buildable || service.Build != nil
+			}
+
+			mu := &sync.Mutex{}
+			wg := sync.WaitGroup{}
+			errs := make([]error, len(images))
+
+			for i, img := range images {
+				wg.Add(1)
+				go func(i int, img *image) {
+					defer wg.Done()
+
+					pull, err := commands.Pull(img.reference)
+					if err != nil {
+						errs[i] = err
+						return
+					}
+
+					// show the progress of every image, one line at a time
+					if !quiet {
+						writer := output.NewLineWriter(os.Stdout, mu, img.reference+" | ")
+						defer writer.Flush()
+						pull.SetOutput(writer)
+					}
+
+					logger.InfoContext(ctx, "pulling image", "image", img.reference, "services", img.services)
+					errs[i] = pull.Exec(ctx)
+				}(i, img)
+			}
+			wg.Wait()
+
+			failed := 0
+			for i, err := range errs {
+				img := images[i]
+				switch {
+				case err == nil:
+					logger.InfoContext(ctx, "pulled image", "image", img.reference)
+				case img.buildable:
+					// the image can still be built locally
+					logger.WarnContext(ctx, "failed to pull image, it will be built instead", "image", img.reference, "error", err)
+				case ignorePullFailures:
+					logger.WarnContext(ctx, "failed to pull image", "image", img.reference, "error", err)
+				default:
+					logger.ErrorContext(ctx, "failed to pull image", "image", img.reference, "error", err)
+					failed++
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to pull %d image(s)", failed)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().BoolVar(&ignorePullFailures, "ignore-pull-failures", false, "pull what is possible and ignore images which fail to pull")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "do not print progress")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
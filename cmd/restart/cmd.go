@@ -0,0 +1,113 @@
+package restart
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	files    []string
+	profiles []string
+	timeout  int
+	cmd      = &cobra.Command{
+		Use:   "restart [SERVICE...]",
+		Short: "Restart services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, logger := logger.New(ctx, os.Stdout, slog.LevelDebug)
+			logger.InfoContext(ctx, "restarting containers", "files", files)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args...)
+
+			services, err := config.Select(args...)
+			if err != nil {
+				return err
+			}
+
+			failed := make(map[string]bool)
+
+			// stop dependents before the services they depend on
+			for i := len(services) - 1; i >= 0; i-- {
+				service := services[i]
+
+				isRunning, err := service.IsRunning(ctx)
+				if err != nil {
+					logger.ErrorContext(ctx, err.Error(), "service", service.ServiceName)
+					failed[service.ServiceName] = true
+					continue
+				}
+				if !isRunning {
+					continue
+				}
+
+				cmd, err := service.StopCommand(ctx)
+				if err == nil {
+					err = cmd.SetTimeout(timeout).Exec(ctx)
+				}
+				if err != nil {
+					logger.ErrorContext(ctx, "failed to stop service", "service", service.ServiceName, "name", service.Name, "error", err)
+					failed[service.ServiceName] = true
+					continue
+				}
+				logger.InfoContext(ctx, "stopped service", "service", service.ServiceName, "name", service.Name)
+			}
+
+			// start them again, dependencies first
+			for _, service := range services {
+				if failed[service.ServiceName] {
+					continue
+				}
+
+				exists, err := service.Exists(ctx)
+				if err != nil {
+					logger.ErrorContext(ctx, err.Error(), "service", service.ServiceName)
+					failed[service.ServiceName] = true
+					continue
+				}
+				if !exists {
+					logger.InfoContext(ctx, "service has no container", "service", service.ServiceName)
+					continue
+				}
+
+				cmd, err := service.StartCommand(ctx)
+				if err == nil {
+					err = cmd.Exec(ctx)
+				}
+				if err != nil {
+					logger.ErrorContext(ctx, "failed to start service", "service", service.ServiceName, "name", service.Name, "error", err)
+					failed[service.ServiceName] = true
+					continue
+				}
+				logger.InfoContext(ctx, "started service", "service", service.ServiceName, "name", service.Name)
+			}
+
+			if len(failed) > 0 {
+				return fmt.Errorf("failed to restart %d service(s)", len(failed))
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 10, "seconds to wait for a service to stop before killing it")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
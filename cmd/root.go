@@ -1,20 +1,56 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/container-compose/cli/cmd/build"
+	"github.com/container-compose/cli/cmd/config"
+	"github.com/container-compose/cli/cmd/down"
+	execcmd "github.com/container-compose/cli/cmd/exec"
+	"github.com/container-compose/cli/cmd/logs"
+	"github.com/container-compose/cli/cmd/ps"
+	"github.com/container-compose/cli/cmd/pull"
+	"github.com/container-compose/cli/cmd/restart"
+	"github.com/container-compose/cli/cmd/run"
 	"github.com/container-compose/cli/cmd/start"
+	"github.com/container-compose/cli/cmd/stop"
+	"github.com/container-compose/cli/internal/problems"
 	"github.com/spf13/cobra"
 )
 
 var (
 	rootCmd = &cobra.Command{
-		Use: "container-compose",
+		Use:           "container-compose",
+		SilenceUsage:  true,
+		SilenceErrors: true,
 	}
 )
 
+// Execute runs the CLI. A process which exited with a non-zero code is passed back as an
+// ExitError without printing anything, every other error is printed.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+
+	var exitErr problems.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+
+	return err
 }
 
 func init() {
+	build.RegisterCommand(rootCmd)
+	config.RegisterCommand(rootCmd)
+	down.RegisterCommand(rootCmd)
+	execcmd.RegisterCommand(rootCmd)
+	logs.RegisterCommand(rootCmd)
+	ps.RegisterCommand(rootCmd)
+	pull.RegisterCommand(rootCmd)
+	restart.RegisterCommand(rootCmd)
+	run.RegisterCommand(rootCmd)
 	start.RegisterCommand(rootCmd)
+	stop.RegisterCommand(rootCmd)
 }
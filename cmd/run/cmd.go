@@ -0,0 +1,180 @@
+package run
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	files        []string
+	profiles     []string
+	remove       bool
+	detach       bool
+	noDeps       bool
+	noTTY        bool
+	servicePorts bool
+	environment  []string
+	entrypoint   string
+	build        bool
+	cmd          = &cobra.Command{
+		Use:   "run SERVICE [COMMAND] [ARG...]",
+		Short: "Run a one-off task in a new container of a service",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, _ = logger.New(ctx, os.Stderr, slog.LevelInfo)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args[0])
+
+			services, err := config.Select(args[0])
+			if err != nil {
+				return err
+			}
+			service := services[0]
+
+			// the task may mount volumes which have not been created yet
+			for _, volume := range config.UsedVolumes() {
+				if _, err := volume.Up(ctx); err != nil {
+					return err
+				}
+			}
+
+			// and attach to networks which have not been created yet
+			for _, network := range config.UsedNetworks() {
+				if _, err := network.Up(ctx); err != nil {
+					return err
+				}
+			}
+
+			// the task needs the services it depends on to be up
+			if !noDeps {
+				dependencies, err := config.Dependencies(service.ServiceName)
+				if err != nil {
+					return err
+				}
+				if err := config.Up(ctx, dependencies); err != nil {
+					return err
+				}
+				if err := config.WaitForDependencies(ctx, service); err != nil {
+					return err
+				}
+			}
+
+			// the one-off container runs the service's image under a name of its own
+			image, err := service.ImageReference(ctx)
+			if err != nil {
+				return err
+			}
+			suffix := make([]byte, 3)
+			if _, err := rand.Read(suffix); err != nil {
+				return err
+			}
+			oneOff := *service
+			oneOff.Image = image
+			oneOff.Name = fmt.Sprintf("%s-%s-run-%s", config.Name, service.ServiceName, hex.EncodeToString(suffix))
+
+			// the service's ports are already taken when it is running
+			if !servicePorts {
+				oneOff.Ports = nil
+			}
+
+			// the command line overrides the service's entrypoint and command
+			if entrypoint != "" {
+				oneOff.Entrypoint, err = entities.ParseShellCommand(entrypoint)
+				if err != nil {
+					return err
+				}
+			}
+			if len(args) > 1 {
+				oneOff.Command = args[1:]
+			}
+
+			// the image is built when it is missing, or again when asked to
+			if build {
+				if err := oneOff.EnsureImage(ctx, true); err != nil {
+					return err
+				}
+			}
+
+			run, err := oneOff.RunCommand(ctx)
+			if err != nil {
+				return err
+			}
+
+			run.Labels[entities.LabelOneOff] = "true"
+
+			env := make(map[string]string)
+			for k, v := range run.EnvironmentVariables {
+				env[k] = v
+			}
+			for _, e := range environment {
+				key, value, found := strings.Cut(e, "=")
+				if !found {
+					value = os.Getenv(key)
+				}
+				env[key] = value
+			}
+			run.EnvironmentVariables = env
+
+			// attach to the terminal unless asked not to
+			run.SetRemove(remove).SetAttach(!detach)
+			if !detach {
+				run.SetInteractive(true).SetTTY(!noTTY && isTerminal(os.Stdin))
+			}
+
+			if err := run.Exec(ctx); err != nil {
+				return err
+			}
+
+			if detach {
+				fmt.Fprintln(cmd.OutOrStdout(), oneOff.Name)
+			}
+
+			return nil
+		},
+	}
+)
+
+// isTerminal reports whether the file is a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().BoolVar(&remove, "rm", false, "remove the container when it exits")
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "run the container in the background and print its name")
+	cmd.Flags().BoolVar(&noDeps, "no-deps", false, "do not start the services the service depends on")
+	cmd.Flags().BoolVarP(&noTTY, "no-TTY", "T", false, "do not allocate a pseudo terminal")
+	cmd.Flags().BoolVar(&servicePorts, "service-ports", false, "publish the service's ports")
+	cmd.Flags().StringArrayVarP(&environment, "env", "e", nil, "set an environment variable, KEY=VAL")
+	cmd.Flags().StringVar(&entrypoint, "entrypoint", "", "override the entrypoint of the image")
+	cmd.Flags().BoolVar(&build, "build", false, "build the image before running, even when it exists")
+
+	// everything after the service name belongs to the command
+	cmd.Flags().SetInterspersed(false)
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
@@ -10,84 +10,62 @@ import (
 )
 
 var (
-	file string
-	cmd  = &cobra.Command{
-		Use: "start",
-		Run: func(cmd *cobra.Command, args []string) {
+	files    []string
+	profiles []string
+	cmd      = &cobra.Command{
+		Use:   "start",
+		Short: "Start services in dependency order",
+		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			ctx, logger := logger.New(ctx, os.Stdout, slog.LevelDebug)
-			logger.InfoContext(ctx, "starting containers", "file", file)
-
-			// attempt to read the file and get the contents
-			contents, err := os.ReadFile(file)
-			if err != nil {
-				logger.ErrorContext(ctx, err.Error())
-				return
-			}
+			logger.InfoContext(ctx, "starting containers", "files", files)
 
 			// parse the config
-			config, err := entities.Parse(contents)
+			config, err := entities.Load(files...)
 			if err != nil {
-				logger.ErrorContext(ctx, err.Error())
-				return
+				return err
 			}
 
-			// start the services
-			for _, service := range config.Services {
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles))
 
-				// check if the service is already running
-				isRunning, err := service.IsRunning(ctx)
-				if err != nil {
-					logger.ErrorContext(ctx, err.Error())
-					return
-				}
-				if isRunning {
-					continue
-				}
+			// sort the services so that dependencies start first
+			services, err := config.Order()
+			if err != nil {
+				return err
+			}
 
-				// if we already have the service, but it's not running, start it
-				exists, err := service.Exists(ctx)
+			// create the volumes the services mount
+			for _, volume := range config.UsedVolumes() {
+				created, err := volume.Up(ctx)
 				if err != nil {
-					logger.ErrorContext(ctx, err.Error())
-					return
+					return err
 				}
-				if exists {
-					// start it back up
-					cmd, err := service.StartCommand(ctx)
-					if err != nil {
-						logger.ErrorContext(ctx, err.Error())
-						return
-					}
-					err = cmd.Exec(ctx)
-					if err != nil {
-						logger.ErrorContext(ctx, err.Error())
-						return
-					}
-					logger.InfoContext(ctx, "started service", "name", service.Name)
-					continue
+				if created {
+					logger.InfoContext(ctx, "created volume", "name", volume.Name)
 				}
+			}
 
-				// start the service
-				cmd, err := service.RunCommand(ctx)
+			// create the networks the services attach to
+			for _, network := range config.UsedNetworks() {
+				created, err := network.Up(ctx)
 				if err != nil {
-					logger.ErrorContext(ctx, err.Error())
-					return
+					return err
 				}
-				err = cmd.Exec(ctx)
-				if err != nil {
-					logger.ErrorContext(ctx, err.Error())
-					return
+				if created {
+					logger.InfoContext(ctx, "created network", "name", network.Name)
 				}
-				logger.InfoContext(ctx, "started service", "name", service.Name)
 			}
 
-			return
+			// start the services
+			return config.Up(ctx, services)
 		},
 	}
 )
 
 func init() {
-	cmd.PersistentFlags().StringVarP(&file, "file", "f", "compose.yaml", "the compose file")
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
 }
 
 func RegisterCommand(parent *cobra.Command) {
@@ -0,0 +1,92 @@
+package stop
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/container-compose/cli/internal/entities"
+	"github.com/container-compose/cli/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	files    []string
+	profiles []string
+	timeout  int
+	cmd      = &cobra.Command{
+		Use:   "stop [SERVICE...]",
+		Short: "Stop services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			ctx, logger := logger.New(ctx, os.Stdout, slog.LevelDebug)
+			logger.InfoContext(ctx, "stopping containers", "files", files)
+
+			// parse the config
+			config, err := entities.Load(files...)
+			if err != nil {
+				return err
+			}
+
+			// leave out the services whose profiles are not enabled
+			config.ApplyProfiles(entities.ActiveProfiles(profiles), args...)
+
+			// select the services, dependents have to stop before their dependencies
+			services, err := config.Select(args...)
+			if err != nil {
+				return err
+			}
+			slices.Reverse(services)
+
+			// stop the services, carrying on past failures so that as much as possible is stopped
+			failed := 0
+			for _, service := range services {
+
+				// nothing to do if the service is not running
+				isRunning, err := service.IsRunning(ctx)
+				if err != nil {
+					logger.ErrorContext(ctx, err.Error(), "service", service.ServiceName)
+					failed++
+					continue
+				}
+				if !isRunning {
+					logger.InfoContext(ctx, "service not running", "service", service.ServiceName, "name", service.Name)
+					continue
+				}
+
+				cmd, err := service.StopCommand(ctx)
+				if err != nil {
+					logger.ErrorContext(ctx, err.Error(), "service", service.ServiceName)
+					failed++
+					continue
+				}
+				cmd.SetTimeout(timeout)
+
+				err = cmd.Exec(ctx)
+				if err != nil {
+					logger.ErrorContext(ctx, "failed to stop service", "service", service.ServiceName, "name", service.Name, "error", err)
+					failed++
+					continue
+				}
+				logger.InfoContext(ctx, "stopped service", "service", service.ServiceName, "name", service.Name)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to stop %d service(s)", failed)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	cmd.PersistentFlags().StringArrayVarP(&files, "file", "f", nil, "the compose files, each overriding the ones before it, defaults to compose.yaml and compose.override.yaml")
+	cmd.PersistentFlags().StringArrayVar(&profiles, "profile", nil, "enable the services in the profile, defaults to COMPOSE_PROFILES")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 10, "seconds to wait for a service to stop before killing it")
+}
+
+func RegisterCommand(parent *cobra.Command) {
+	parent.AddCommand(cmd)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strconv"
 
@@ -25,6 +26,7 @@ type BuildCommand struct {
 	Arch       string
 	OS         string
 	Progress   string
+	Output     io.Writer
 }
 
 func Build(context string) (*BuildCommand, error) {
@@ -34,8 +36,6 @@ func Build(context string) (*BuildCommand, error) {
 
 	return &BuildCommand{
 		Context:  context,
-		CPUs:     2,
-		Memory:   "2048MB",
 		Arch:     "arm64",
 		OS:       "linux",
 		Progress: "auto",
@@ -120,6 +120,12 @@ func (c *BuildCommand) SetProgress(progress string) *BuildCommand {
 	return c
 }
 
+// SetOutput streams the build output to the writer as it arrives
+func (c *BuildCommand) SetOutput(output io.Writer) *BuildCommand {
+	c.Output = output
+	return c
+}
+
 // Exec executes the build command
 func (c *BuildCommand) Exec(ctx context.Context) error {
 	args := []string{
@@ -156,6 +162,11 @@ func (c *BuildCommand) Exec(ctx context.Context) error {
 		args = append(args, "--no-cache")
 	}
 
+	// Add pull flag
+	if c.Pull {
+		args = append(args, "--pull")
+	}
+
 	// Add architecture
 	if c.Arch != "" {
 		args = append(args, "--arch", c.Arch)
@@ -198,6 +209,11 @@ func (c *BuildCommand) Exec(ctx context.Context) error {
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
+	if c.Output != nil {
+		cmd.Stdout = c.Output
+		cmd.Stderr = io.MultiWriter(stderr, c.Output)
+	}
+
 	err := cmd.Run()
 	if err != nil {
 		return problems.Convert(stderr.String())
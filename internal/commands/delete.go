@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type DeleteCommand struct {
+	ID    string
+	Force bool
+}
+
+func Delete(id string) (*DeleteCommand, error) {
+	if id == "" {
+		return nil, problems.ErrIDCannotBeEmpty
+	}
+
+	return &DeleteCommand{
+		ID: id,
+	}, nil
+}
+
+// SetForce deletes the container even when it is running
+func (c *DeleteCommand) SetForce(force bool) *DeleteCommand {
+	c.Force = force
+	return c
+}
+
+// Exec executes the delete command
+func (c *DeleteCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"delete",
+	}
+
+	if c.Force {
+		args = append(args, "--force")
+	}
+
+	args = append(args, c.ID)
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type ExecCommand struct {
+	ID          string
+	Command     []string
+	Interactive bool
+	TTY         bool
+	Environment []string
+	WorkingDir  string
+	User        string
+	Output      io.Writer
+}
+
+func Exec(id string, command []string) (*ExecCommand, error) {
+	if id == "" {
+		return nil, problems.ErrIDCannotBeEmpty
+	}
+
+	if len(command) == 0 {
+		return nil, problems.ErrCommandCannotBeEmpty
+	}
+
+	return &ExecCommand{
+		ID:      id,
+		Command: command,
+	}, nil
+}
+
+// SetInteractive keeps stdin open
+func (c *ExecCommand) SetInteractive(interactive bool) *ExecCommand {
+	c.Interactive = interactive
+	return c
+}
+
+// SetTTY allocates a pseudo terminal
+func (c *ExecCommand) SetTTY(tty bool) *ExecCommand {
+	c.TTY = tty
+	return c
+}
+
+// SetEnvironment sets environment variables in KEY=VALUE form
+func (c *ExecCommand) SetEnvironment(env []string) *ExecCommand {
+	c.Environment = env
+	return c
+}
+
+// SetWorkingDir sets the directory the command runs in
+func (c *ExecCommand) SetWorkingDir(dir string) *ExecCommand {
+	c.WorkingDir = dir
+	return c
+}
+
+// SetUser sets the user the command runs as
+func (c *ExecCommand) SetUser(user string) *ExecCommand {
+	c.User = user
+	return c
+}
+
+// SetOutput sends the output of the command to the writer instead of the terminal, and
+// leaves stdin unconnected
+func (c *ExecCommand) SetOutput(output io.Writer) *ExecCommand {
+	c.Output = output
+	return c
+}
+
+// Exec executes the command inside the container. Unlike the other commands the standard
+// streams are connected directly to the process so that it can be used interactively. The
+// exit code of the process is returned.
+func (c *ExecCommand) Exec(ctx context.Context) (int, error) {
+
+	args := []string{
+		"exec",
+	}
+
+	if c.Interactive {
+		args = append(args, "--interactive")
+	}
+
+	if c.TTY {
+		args = append(args, "--tty")
+	}
+
+	for _, env := range c.Environment {
+		args = append(args, "--env", env)
+	}
+
+	if c.WorkingDir != "" {
+		args = append(args, "--workdir", c.WorkingDir)
+	}
+
+	if c.User != "" {
+		args = append(args, "--user", c.User)
+	}
+
+	args = append(args, c.ID)
+	args = append(args, c.Command...)
+
+	cmd := exec.CommandContext(ctx, "container", args...)
+
+	// once the context is done, stop waiting for output held open by anything the command
+	// started
+	cmd.WaitDelay = time.Second
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if c.Output != nil {
+		cmd.Stdin = nil
+		cmd.Stdout = c.Output
+		cmd.Stderr = c.Output
+	}
+
+	err := cmd.Run()
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return -1, err
+	}
+
+	return 0, nil
+}
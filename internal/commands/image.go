@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type ImageDeleteCommand struct {
+	Reference string
+}
+
+func ImageDelete(reference string) (*ImageDeleteCommand, error) {
+	if reference == "" {
+		return nil, problems.ErrReferenceCannotBeEmpty
+	}
+
+	return &ImageDeleteCommand{
+		Reference: reference,
+	}, nil
+}
+
+// Exec executes the image delete command
+func (c *ImageDeleteCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"image",
+		"delete",
+		c.Reference,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
+
+type PullCommand struct {
+	Reference string
+	Output    io.Writer
+}
+
+func Pull(reference string) (*PullCommand, error) {
+	if reference == "" {
+		return nil, problems.ErrReferenceCannotBeEmpty
+	}
+
+	return &PullCommand{
+		Reference: reference,
+	}, nil
+}
+
+// SetOutput streams the pull progress to the writer as it arrives
+func (c *PullCommand) SetOutput(output io.Writer) *PullCommand {
+	c.Output = output
+	return c
+}
+
+// Exec executes the image pull command
+func (c *PullCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"image",
+		"pull",
+		c.Reference,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if c.Output != nil {
+		cmd.Stdout = c.Output
+		cmd.Stderr = io.MultiWriter(stderr, c.Output)
+	}
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
+
+type ImageInspectCommand struct {
+	Reference string
+}
+
+func ImageInspect(reference string) (*ImageInspectCommand, error) {
+	if reference == "" {
+		return nil, problems.ErrReferenceCannotBeEmpty
+	}
+
+	return &ImageInspectCommand{
+		Reference: reference,
+	}, nil
+}
+
+// Exec executes the image inspect command, which fails when the image does not exist
+func (c *ImageInspectCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"image",
+		"inspect",
+		c.Reference,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
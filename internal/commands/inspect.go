@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os/exec"
+	"strconv"
+	"time"
 
 	"github.com/container-compose/cli/internal/problems"
 )
@@ -16,24 +19,135 @@ type InspectCommand struct {
 // InspectResult represents the JSON output from the container inspect command
 type InspectResult struct {
 	Configuration Configuration `json:"configuration"`
-	Networks      []interface{} `json:"networks"`
+	Networks      []Attachment  `json:"networks"`
 	Status        string        `json:"status"`
+	StartedDate   *Date         `json:"startedDate,omitempty"`
 }
 
 type Configuration struct {
 	DNS            DNS                    `json:"dns"`
-	Networks       []string               `json:"networks"`
+	Networks       []NetworkConfiguration `json:"networks"`
 	Labels         map[string]string      `json:"labels"`
 	Image          Image                  `json:"image"`
 	Platform       Platform               `json:"platform"`
 	Resources      Resources              `json:"resources"`
 	InitProcess    InitProcess            `json:"initProcess"`
 	Hostname       string                 `json:"hostname"`
-	Mounts         []interface{}          `json:"mounts"`
+	Mounts         []Mount                `json:"mounts"`
 	Rosetta        bool                   `json:"rosetta"`
 	RuntimeHandler string                 `json:"runtimeHandler"`
 	ID             string                 `json:"id"`
 	Sysctls        map[string]interface{} `json:"sysctls"`
+	PublishedPorts []PublishedPort        `json:"publishedPorts"`
+}
+
+type PublishedPort struct {
+	HostAddress   string `json:"hostAddress"`
+	HostPort      int    `json:"hostPort"`
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"proto"`
+	Count         int    `json:"count"`
+}
+
+// String formats the port the same way it is passed to --publish
+func (p PublishedPort) String() string {
+	host := strconv.Itoa(p.HostPort)
+	container := strconv.Itoa(p.ContainerPort)
+	if p.Count > 1 {
+		host = fmt.Sprintf("%d-%d", p.HostPort, p.HostPort+p.Count-1)
+		container = fmt.Sprintf("%d-%d", p.ContainerPort, p.ContainerPort+p.Count-1)
+	}
+
+	protocol := p.Protocol
+	if protocol == "" {
+		protocol = "tcp"
+	}
+
+	if p.HostAddress != "" {
+		return fmt.Sprintf("%s:%s->%s/%s", p.HostAddress, host, container, protocol)
+	}
+	return fmt.Sprintf("%s->%s/%s", host, container, protocol)
+}
+
+// Date is a timestamp reported by the container engine. The engine encodes dates either as
+// seconds since 2001-01-01 (the Swift reference date) or as an RFC 3339 string.
+type Date struct {
+	time.Time
+}
+
+// referenceDate is the Swift reference date which numeric dates are relative to
+var referenceDate = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// UnmarshalJSON implements custom JSON unmarshaling for Date which handles both the
+// numeric and string encodings
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err == nil {
+		d.Time = referenceDate.Add(time.Duration(seconds * float64(time.Second)))
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return err
+	}
+	d.Time = parsed
+	return nil
+}
+
+// Attachment is a network the running container is attached to and its address on it
+type Attachment struct {
+	Network  string `json:"network"`
+	Hostname string `json:"hostname"`
+	Address  string `json:"address"`
+	Gateway  string `json:"gateway"`
+}
+
+// NetworkConfiguration is a network the container is configured to attach to
+type NetworkConfiguration struct {
+	Network string         `json:"network"`
+	Options NetworkOptions `json:"options"`
+}
+
+type NetworkOptions struct {
+	Hostname string `json:"hostname"`
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for NetworkConfiguration which handles
+// both the plain network name older engines report and the object newer engines report
+func (n *NetworkConfiguration) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		n.Network = name
+		return nil
+	}
+
+	type networkConfigurationAlias NetworkConfiguration
+	return json.Unmarshal(data, (*networkConfigurationAlias)(n))
+}
+
+// Mount is a filesystem mounted into the container. The type is keyed by the kind of
+// filesystem, e.g. virtiofs for host directories, tmpfs or volume.
+type Mount struct {
+	Type        map[string]json.RawMessage `json:"type"`
+	Source      string                     `json:"source"`
+	Destination string                     `json:"destination"`
+	Options     []string                   `json:"options"`
+}
+
+// ReadOnly reports whether the mount is read only
+func (m Mount) ReadOnly() bool {
+	for _, option := range m.Options {
+		if option == "ro" || option == "readonly" {
+			return true
+		}
+	}
+	return false
 }
 
 type DNS struct {
@@ -75,8 +189,10 @@ type InitProcess struct {
 	Rlimits            []interface{} `json:"rlimits"`
 }
 
+// User is the user a process runs as, either by id or by the name it was given as.
 type User struct {
-	ID UserID `json:"id"`
+	ID  *UserID  `json:"id,omitempty"`
+	Raw *UserRaw `json:"raw,omitempty"`
 }
 
 type UserID struct {
@@ -84,6 +200,21 @@ type UserID struct {
 	GID int `json:"gid"`
 }
 
+type UserRaw struct {
+	UserString string `json:"userString"`
+}
+
+// String formats the user the way it is passed to run, name or uid:gid.
+func (u User) String() string {
+	switch {
+	case u.Raw != nil:
+		return u.Raw.UserString
+	case u.ID != nil:
+		return fmt.Sprintf("%d:%d", u.ID.UID, u.ID.GID)
+	}
+	return ""
+}
+
 func Inspect(id string) (*InspectCommand, error) {
 	if id == "" {
 		return nil, problems.ErrIDCannotBeEmpty
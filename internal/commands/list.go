@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type ListCommand struct {
+	All bool
+}
+
+func List() (*ListCommand, error) {
+	return &ListCommand{}, nil
+}
+
+// SetAll includes containers that are not running
+func (c *ListCommand) SetAll(all bool) *ListCommand {
+	c.All = all
+	return c
+}
+
+// Exec executes the list command and returns the parsed result
+func (c *ListCommand) Exec(ctx context.Context) ([]InspectResult, error) {
+	args := []string{
+		"list",
+		"--format", "json",
+	}
+
+	if c.All {
+		args = append(args, "--all")
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, problems.Convert(stderr.String())
+	}
+
+	// Parse the JSON output
+	var results []InspectResult
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type LogsCommand struct {
+	ID     string
+	Follow bool
+	Tail   int
+}
+
+func Logs(id string) (*LogsCommand, error) {
+	if id == "" {
+		return nil, problems.ErrIDCannotBeEmpty
+	}
+
+	return &LogsCommand{
+		ID:   id,
+		Tail: -1,
+	}, nil
+}
+
+// SetFollow keeps streaming new output until the context is cancelled
+func (c *LogsCommand) SetFollow(follow bool) *LogsCommand {
+	c.Follow = follow
+	return c
+}
+
+// SetTail limits the output to the last n lines, a negative value shows every line
+func (c *LogsCommand) SetTail(n int) *LogsCommand {
+	c.Tail = n
+	return c
+}
+
+// Exec executes the logs command, streaming the container's output to the writer as it
+// arrives rather than buffering it
+func (c *LogsCommand) Exec(ctx context.Context, output io.Writer) error {
+
+	args := []string{
+		"logs",
+	}
+
+	if c.Follow {
+		args = append(args, "--follow")
+	}
+
+	if c.Tail >= 0 {
+		args = append(args, "-n", strconv.Itoa(c.Tail))
+	}
+
+	args = append(args, c.ID)
+
+	cmd := exec.CommandContext(ctx, "container", args...)
+
+	// stream stdout and capture stderr to convert failures
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = output
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil // stopped following
+		}
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type NetworkDeleteCommand struct {
+	Name string
+}
+
+func NetworkDelete(name string) (*NetworkDeleteCommand, error) {
+	if name == "" {
+		return nil, problems.ErrNameCannotBeEmpty
+	}
+
+	return &NetworkDeleteCommand{
+		Name: name,
+	}, nil
+}
+
+// Exec executes the network delete command
+func (c *NetworkDeleteCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"network",
+		"delete",
+		c.Name,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
+
+type NetworkCreateCommand struct {
+	Name   string
+	Labels map[string]string
+}
+
+func NetworkCreate(name string) (*NetworkCreateCommand, error) {
+	if name == "" {
+		return nil, problems.ErrNameCannotBeEmpty
+	}
+
+	return &NetworkCreateCommand{
+		Name: name,
+	}, nil
+}
+
+// SetLabels sets labels on the network
+func (c *NetworkCreateCommand) SetLabels(labels map[string]string) *NetworkCreateCommand {
+	c.Labels = labels
+	return c
+}
+
+// Exec executes the network create command
+func (c *NetworkCreateCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"network",
+		"create",
+	}
+
+	for key, value := range c.Labels {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", key, value))
+	}
+
+	args = append(args, c.Name)
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
+
+type NetworkInspectCommand struct {
+	Name string
+}
+
+func NetworkInspect(name string) (*NetworkInspectCommand, error) {
+	if name == "" {
+		return nil, problems.ErrNameCannotBeEmpty
+	}
+
+	return &NetworkInspectCommand{
+		Name: name,
+	}, nil
+}
+
+// Exec executes the network inspect command, which fails when the network does not exist
+func (c *NetworkInspectCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"network",
+		"inspect",
+		c.Name,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
@@ -3,21 +3,36 @@ package commands
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"strconv"
 
 	"github.com/container-compose/cli/internal/problems"
 )
 
 type RunCommand struct {
-	Name   string
-	Attach bool
-	// Interactive    bool
+	Name        string
+	Attach      bool
+	Interactive bool
+	TTY         bool
+	Remove      bool
 	// Debug          bool
 	// Version        bool
 	ContainerImage       string
 	EnvironmentVariables map[string]string
 	Labels               map[string]string
+	Entrypoint           string
+	Arguments            []string
+	WorkingDir           string
+	User                 string
+	CPUs                 int
+	Memory               string
+	PublishedPorts       []string
+	Volumes              []string
+	Mounts               []string
+	Networks             []string
 }
 
 func (c *RunCommand) Image(image string) *RunCommand {
@@ -37,7 +52,92 @@ func Run(name string, environmentVariables map[string]string, labels map[string]
 	}, nil
 }
 
-// Exec executes the run command
+// SetAttach runs the container in the foreground with the standard streams connected to it
+func (c *RunCommand) SetAttach(attach bool) *RunCommand {
+	c.Attach = attach
+	return c
+}
+
+// SetInteractive keeps stdin open
+func (c *RunCommand) SetInteractive(interactive bool) *RunCommand {
+	c.Interactive = interactive
+	return c
+}
+
+// SetTTY allocates a pseudo terminal
+func (c *RunCommand) SetTTY(tty bool) *RunCommand {
+	c.TTY = tty
+	return c
+}
+
+// SetRemove removes the container when it exits
+func (c *RunCommand) SetRemove(remove bool) *RunCommand {
+	c.Remove = remove
+	return c
+}
+
+// SetEntrypoint overrides the entrypoint of the image
+func (c *RunCommand) SetEntrypoint(entrypoint string) *RunCommand {
+	c.Entrypoint = entrypoint
+	return c
+}
+
+// SetArguments sets the command passed to the entrypoint
+func (c *RunCommand) SetArguments(arguments []string) *RunCommand {
+	c.Arguments = arguments
+	return c
+}
+
+// SetWorkingDir sets the directory the process runs in
+func (c *RunCommand) SetWorkingDir(dir string) *RunCommand {
+	c.WorkingDir = dir
+	return c
+}
+
+// SetUser sets the user the process runs as, name or uid[:gid]
+func (c *RunCommand) SetUser(user string) *RunCommand {
+	c.User = user
+	return c
+}
+
+// SetCPUs sets the number of CPUs to allocate
+func (c *RunCommand) SetCPUs(cpus int) *RunCommand {
+	c.CPUs = cpus
+	return c
+}
+
+// SetMemory sets the memory limit, e.g. 512MB
+func (c *RunCommand) SetMemory(memory string) *RunCommand {
+	c.Memory = memory
+	return c
+}
+
+// SetPublish sets the ports to publish, each in the form [host-ip:]host-port:container-port[/protocol]
+func (c *RunCommand) SetPublish(ports []string) *RunCommand {
+	c.PublishedPorts = ports
+	return c
+}
+
+// SetVolumes sets the volumes to mount, each in the form source:target[:ro]
+func (c *RunCommand) SetVolumes(volumes []string) *RunCommand {
+	c.Volumes = volumes
+	return c
+}
+
+// SetMounts sets the mounts, each in the form type=TYPE,source=SOURCE,target=TARGET[,readonly]
+func (c *RunCommand) SetMounts(mounts []string) *RunCommand {
+	c.Mounts = mounts
+	return c
+}
+
+// SetNetworks sets the networks to attach the container to
+func (c *RunCommand) SetNetworks(networks []string) *RunCommand {
+	c.Networks = networks
+	return c
+}
+
+// Exec executes the run command. An attached container's exit code is returned as an
+// ExitError when it is not zero.
 func (c *RunCommand) Exec(ctx context.Context) error {
 
 	args := []string{
@@ -49,6 +149,18 @@ func (c *RunCommand) Exec(ctx context.Context) error {
 		args = append(args, "--detach")
 	}
 
+	if c.Interactive {
+		args = append(args, "--interactive")
+	}
+
+	if c.TTY {
+		args = append(args, "--tty")
+	}
+
+	if c.Remove {
+		args = append(args, "--rm")
+	}
+
 	for key, value := range c.EnvironmentVariables {
 		args = append(args, "--env", fmt.Sprintf("%s=%s", key, value))
 	}
@@ -57,9 +169,51 @@ func (c *RunCommand) Exec(ctx context.Context) error {
 		args = append(args, "--label", fmt.Sprintf("%s=%s", key, value))
 	}
 
+	for _, port := range c.PublishedPorts {
+		args = append(args, "--publish", port)
+	}
+
+	for _, volume := range c.Volumes {
+		args = append(args, "--volume", volume)
+	}
+
+	for _, mount := range c.Mounts {
+		args = append(args, "--mount", mount)
+	}
+
+	for _, network := range c.Networks {
+		args = append(args, "--network", network)
+	}
+
+	if c.Entrypoint != "" {
+		args = append(args, "--entrypoint", c.Entrypoint)
+	}
+
+	if c.WorkingDir != "" {
+		args = append(args, "--workdir", c.WorkingDir)
+	}
+
+	if c.User != "" {
+		args = append(args, "--user", c.User)
+	}
+
+	if c.CPUs > 0 {
+		args = append(args, "--cpus", strconv.Itoa(c.CPUs))
+	}
+
+	if c.Memory != "" {
+		args = append(args, "--memory", c.Memory)
+	}
+
 	args = append(args, c.ContainerImage)
+	args = append(args, c.Arguments...)
 	cmd := exec.Command("container", args...)
 
+	// an attached container is connected straight to the terminal
+	if c.Attach {
+		return runAttached(cmd, c.Interactive)
+	}
+
 	// create io writers to capture the exec output
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -74,3 +228,20 @@ func (c *RunCommand) Exec(ctx context.Context) error {
 
 	return nil
 }
+
+// runAttached runs the command with its output connected to the terminal, and stdin as well
+// when it is interactive. A non-zero exit code is returned as an ExitError.
+func runAttached(cmd *exec.Cmd, interactive bool) error {
+	if interactive {
+		cmd.Stdin = os.Stdin
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return problems.ExitError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type StartCommand struct {
-	ID string
+	ID     string
+	Attach bool
 }
 
 func Start(id string) (*StartCommand, error) {
@@ -22,16 +23,32 @@ func Start(id string) (*StartCommand, error) {
 	}, nil
 }
 
-// Exec executes the start command
+// SetAttach waits for the container to exit with its output connected to the terminal
+func (c *StartCommand) SetAttach(attach bool) *StartCommand {
+	c.Attach = attach
+	return c
+}
+
+// Exec executes the start command. An attached container's exit code is returned as an
+// ExitError when it is not zero.
 func (c *StartCommand) Exec(ctx context.Context) error {
 
 	args := []string{
 		"start",
-		c.ID,
 	}
 
+	if c.Attach {
+		args = append(args, "--attach")
+	}
+
+	args = append(args, c.ID)
+
 	cmd := exec.Command("container", args...)
 
+	if c.Attach {
+		return runAttached(cmd, false)
+	}
+
 	// create io writers to capture the exec output
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"os/exec"
+	"strconv"
 
 	"github.com/container-compose/cli/internal/problems"
 )
 
 type StopCommand struct {
-	ID string
+	ID      string
+	Timeout int // -1 leaves the timeout to the container engine
 }
 
 func Stop(id string) (*StopCommand, error) {
@@ -18,18 +20,31 @@ func Stop(id string) (*StopCommand, error) {
 	}
 
 	return &StopCommand{
-		ID: id,
+		ID:      id,
+		Timeout: -1,
 	}, nil
 }
 
+// SetTimeout sets the number of seconds to wait before the container is killed, 0 kills it
+// straight away
+func (c *StopCommand) SetTimeout(timeout int) *StopCommand {
+	c.Timeout = timeout
+	return c
+}
+
 // Exec executes the stop command
 func (c *StopCommand) Exec(ctx context.Context) error {
 
 	args := []string{
 		"stop",
-		c.ID,
 	}
 
+	if c.Timeout >= 0 {
+		args = append(args, "--time", strconv.Itoa(c.Timeout))
+	}
+
+	args = append(args, c.ID)
+
 	cmd := exec.Command("container", args...)
 
 	// create io writers to capture the exec output
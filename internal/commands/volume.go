@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/container-compose/cli/internal/problems"
+)
+
+type VolumeDeleteCommand struct {
+	Name string
+}
+
+func VolumeDelete(name string) (*VolumeDeleteCommand, error) {
+	if name == "" {
+		return nil, problems.ErrNameCannotBeEmpty
+	}
+
+	return &VolumeDeleteCommand{
+		Name: name,
+	}, nil
+}
+
+// Exec executes the volume delete command
+func (c *VolumeDeleteCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"volume",
+		"delete",
+		c.Name,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
+
+type VolumeCreateCommand struct {
+	Name    string
+	Labels  map[string]string
+	Options map[string]string
+}
+
+func VolumeCreate(name string) (*VolumeCreateCommand, error) {
+	if name == "" {
+		return nil, problems.ErrNameCannotBeEmpty
+	}
+
+	return &VolumeCreateCommand{
+		Name: name,
+	}, nil
+}
+
+// SetLabels sets labels on the volume
+func (c *VolumeCreateCommand) SetLabels(labels map[string]string) *VolumeCreateCommand {
+	c.Labels = labels
+	return c
+}
+
+// SetOptions sets driver specific options
+func (c *VolumeCreateCommand) SetOptions(options map[string]string) *VolumeCreateCommand {
+	c.Options = options
+	return c
+}
+
+// Exec executes the volume create command
+func (c *VolumeCreateCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"volume",
+		"create",
+	}
+
+	for key, value := range c.Labels {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", key, value))
+	}
+
+	for key, value := range c.Options {
+		args = append(args, "--opt", fmt.Sprintf("%s=%s", key, value))
+	}
+
+	args = append(args, c.Name)
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
+
+type VolumeInspectCommand struct {
+	Name string
+}
+
+func VolumeInspect(name string) (*VolumeInspectCommand, error) {
+	if name == "" {
+		return nil, problems.ErrNameCannotBeEmpty
+	}
+
+	return &VolumeInspectCommand{
+		Name: name,
+	}, nil
+}
+
+// Exec executes the volume inspect command, which fails when the volume does not exist
+func (c *VolumeInspectCommand) Exec(ctx context.Context) error {
+
+	args := []string{
+		"volume",
+		"inspect",
+		c.Name,
+	}
+
+	cmd := exec.Command("container", args...)
+
+	// create io writers to capture the exec output
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return problems.Convert(stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ShellCommand is a command and its arguments, such as a service's command or entrypoint.
+type ShellCommand []string
+
+// UnmarshalYAML implements custom YAML unmarshaling for ShellCommand which handles both a
+// list of arguments and a string, which is split into arguments the way a shell would
+func (s *ShellCommand) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		command, err := ParseShellCommand(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*s = command
+		return nil
+	}
+
+	if value.Kind == yaml.SequenceNode {
+		var command []string
+		if err := value.Decode(&command); err != nil {
+			return err
+		}
+		*s = command
+		return nil
+	}
+
+	return fmt.Errorf("line %d: command must be either a string or a list", value.Line)
+}
+
+// ParseShellCommand splits the command into arguments the way a shell would: arguments are
+// separated by whitespace, single quotes keep everything inside them, double quotes keep
+// everything but backslash escapes and a backslash escapes the character after it.
+func ParseShellCommand(command string) (ShellCommand, error) {
+	var arguments ShellCommand
+	var current strings.Builder
+	inArgument := false
+
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArgument {
+				arguments = append(arguments, current.String())
+				current.Reset()
+				inArgument = false
+			}
+
+		case c == '\\':
+			if i+1 == len(command) {
+				return nil, fmt.Errorf("invalid command %q: ends with a backslash", command)
+			}
+			i++
+			current.WriteByte(command[i])
+			inArgument = true
+
+		case c == '\'':
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid command %q: unterminated single quote", command)
+			}
+			current.WriteString(command[i+1 : i+1+end])
+			i += end + 1
+			inArgument = true
+
+		case c == '"':
+			i++
+			for ; i < len(command) && command[i] != '"'; i++ {
+				if command[i] == '\\' && i+1 < len(command) && strings.IndexByte(`"\$`+"`", command[i+1]) >= 0 {
+					i++
+				}
+				current.WriteByte(command[i])
+			}
+			if i == len(command) {
+				return nil, fmt.Errorf("invalid command %q: unterminated double quote", command)
+			}
+			inArgument = true
+
+		default:
+			current.WriteByte(c)
+			inArgument = true
+		}
+	}
+
+	if inArgument {
+		arguments = append(arguments, current.String())
+	}
+
+	return arguments, nil
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseShellCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    ShellCommand
+		wantErr bool
+	}{
+		{command: "", want: nil},
+		{command: "   ", want: nil},
+		{command: "npm start", want: ShellCommand{"npm", "start"}},
+		{command: "  npm\t run \n dev ", want: ShellCommand{"npm", "run", "dev"}},
+		{command: `echo 'hello world'`, want: ShellCommand{"echo", "hello world"}},
+		{command: `echo "hello world"`, want: ShellCommand{"echo", "hello world"}},
+		{command: `echo ''`, want: ShellCommand{"echo", ""}},
+		{command: `echo ""`, want: ShellCommand{"echo", ""}},
+		{command: `echo 'a "b" c'`, want: ShellCommand{"echo", `a "b" c`}},
+		{command: `echo "it's"`, want: ShellCommand{"echo", "it's"}},
+		{command: `echo '\n'`, want: ShellCommand{"echo", `\n`}},
+		{command: `echo "a \"b\" \$HOME \\ \n"`, want: ShellCommand{"echo", `a "b" $HOME \ \n`}},
+		{command: `echo hello\ world`, want: ShellCommand{"echo", "hello world"}},
+		{command: `echo pre'fix'"ed"`, want: ShellCommand{"echo", "prefixed"}},
+		{command: `sh -c 'echo $HOME && ls'`, want: ShellCommand{"sh", "-c", "echo $HOME && ls"}},
+		{command: `echo 'unterminated`, wantErr: true},
+		{command: `echo "unterminated`, wantErr: true},
+		{command: `echo trailing\`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			got, err := ParseShellCommand(tt.command)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseShellCommand(%q) = %q, want an error", tt.command, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseShellCommand(%q) returned an error: %v", tt.command, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseShellCommand(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellCommandUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    ShellCommand
+		wantErr bool
+	}{
+		{name: "string", yaml: `echo "hello world"`, want: ShellCommand{"echo", "hello world"}},
+		{name: "list", yaml: `["echo", "hello world"]`, want: ShellCommand{"echo", "hello world"}},
+		{name: "map", yaml: `{echo: hello}`, wantErr: true},
+		{name: "bad quoting", yaml: `"echo 'hello"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ShellCommand
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %q, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unmarshaling %s gave %q, want %q", tt.yaml, got, tt.want)
+			}
+		})
+	}
+}
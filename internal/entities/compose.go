@@ -1,16 +1,278 @@
 package entities
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/container-compose/cli/internal/commands"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// LabelProject is set on every container to the name of the project it belongs to
+	LabelProject = "com.container-compose.project"
+	// LabelService is set on every container to the name of the service it runs
+	LabelService = "com.container-compose.service"
+	// LabelOneOff is set to "true" on containers created by run for a single task
+	LabelOneOff = "com.container-compose.oneoff"
+)
+
 type Compose struct {
-	Version  string              `yaml:"version"`
+	WorkingDir string `yaml:"-"`
+
+	Name     string              `yaml:"name,omitempty"`
+	Version  string              `yaml:"version,omitempty"`
+	Include  []Include           `yaml:"include,omitempty"`
 	Services map[string]*Service `yaml:"services"`
+	Volumes  map[string]*Volume  `yaml:"volumes,omitempty"`
+	Networks map[string]*Network `yaml:"networks,omitempty"`
+
+	// Disabled holds the services whose profiles are not enabled
+	Disabled map[string]*Service `yaml:"-"`
 }
 
-func Parse(content []byte) (Compose, error) {
+// Parse parses a compose file, substituting the variables which lookup returns into it.
+func Parse(content []byte, lookup func(string) (string, bool)) (Compose, error) {
 	config := Compose{}
-	err := yaml.Unmarshal(content, &config)
-	return config, err
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return config, err
+	}
+
+	// an empty file has no document at all
+	if document.Kind != 0 {
+		if err := Interpolate(&document, lookup); err != nil {
+			return config, err
+		}
+		if err := document.Decode(&config); err != nil {
+			return config, err
+		}
+	}
+
+	// give every service its key so it can be referred to by name
+	for key, service := range config.Services {
+		if service == nil {
+			service = &Service{}
+			config.Services[key] = service
+		}
+		service.ServiceName = key
+	}
+
+	for key, volume := range config.Volumes {
+		if volume == nil {
+			volume = &Volume{}
+			config.Volumes[key] = volume
+		}
+		volume.Key = key
+	}
+
+	for key, network := range config.Networks {
+		if network == nil {
+			network = &Network{}
+			config.Networks[key] = network
+		}
+		network.Key = key
+	}
+
+	config.SetProject(config.Name)
+
+	return config, nil
+}
+
+// DefaultFile is the compose file read when no file is given.
+const DefaultFile = "compose.yaml"
+
+// Load reads the compose files at the given paths and parses them, each file overriding the
+// ones before it. When no path is given, DefaultFile is read, overridden by
+// compose.override.yaml when there is one. Relative paths are relative to the directory of
+// the first file. Variables are substituted from the process environment and the .env file
+// in that directory. When the files do not name the project, the name of the directory is
+// used.
+func Load(paths ...string) (Compose, error) {
+	// the override file is only read along with the default file, files given explicitly
+	// are read as they are
+	if len(paths) == 0 {
+		paths = []string{DefaultFile}
+		if override := overridePath(DefaultFile); override != "" {
+			paths = append(paths, override)
+		}
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(paths[0]))
+	if err != nil {
+		return Compose{}, err
+	}
+
+	environment, err := LoadEnvironment(dir)
+	if err != nil {
+		return Compose{}, err
+	}
+
+	var config Compose
+	for i, path := range paths {
+		file, err := parseFile(path, environment.Lookup, nil)
+		if err != nil {
+			return Compose{}, err
+		}
+
+		if i == 0 {
+			config = file
+			continue
+		}
+		config.Merge(file)
+	}
+	config.SetProject(config.Name)
+
+	if config.Name == "" {
+		config.SetProject(filepath.Base(dir))
+	}
+
+	if err := config.ResolvePaths(dir); err != nil {
+		return config, err
+	}
+
+	if err := config.ResolveEnvFiles(environment.Lookup); err != nil {
+		return config, err
+	}
+
+	if err := config.ResolveVolumes(); err != nil {
+		return config, err
+	}
+
+	if err := config.ResolveNetworks(); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// overridePath returns the path of the override file for the compose file at path, or an
+// empty string when there is none.
+func overridePath(path string) string {
+	extension := filepath.Ext(path)
+	override := strings.TrimSuffix(path, extension) + ".override" + extension
+	if _, err := os.Stat(override); err != nil {
+		return ""
+	}
+	return override
+}
+
+// ResolvePaths makes the host paths in the compose file absolute, relative paths being
+// relative to dir. This covers bind mounts, build contexts and env files.
+func (c *Compose) ResolvePaths(dir string) error {
+	c.WorkingDir = dir
+
+	for _, service := range c.Services {
+		for i := range service.Volumes {
+			if err := service.Volumes[i].Resolve(dir); err != nil {
+				return err
+			}
+		}
+
+		for i := range service.EnvFile {
+			path, err := resolvePath(service.EnvFile[i].Path, dir)
+			if err != nil {
+				return err
+			}
+			service.EnvFile[i].Path = path
+		}
+
+		// build contexts may also be remote, which are left as they are
+		if service.Build != nil && !strings.Contains(service.Build.Context, "://") {
+			if service.Build.Context == "" {
+				service.Build.Context = "."
+			}
+			context, err := resolvePath(service.Build.Context, dir)
+			if err != nil {
+				return err
+			}
+			service.Build.Context = context
+		}
+	}
+
+	return nil
+}
+
+// SetProject sets the project name on the compose file and all of its services.
+func (c *Compose) SetProject(name string) {
+	c.Name = normalizeProjectName(name)
+	for _, service := range c.Services {
+		service.Project = c.Name
+	}
+	for _, volume := range c.Volumes {
+		volume.Project = c.Name
+	}
+	for _, network := range c.Networks {
+		network.Project = c.Name
+	}
+}
+
+// Normalize fills in everything which the compose file leaves to a default: the generated
+// container names, the build contexts, the tags of images which are only built and the
+// labels set on each container.
+func (c *Compose) Normalize(ctx context.Context) error {
+	for _, service := range c.Services {
+
+		// the name is generated from the configuration, so it has to come first
+		if service.Name == "" {
+			generated, err := service.GenerateName(ctx)
+			if err != nil {
+				return err
+			}
+			service.Name = generated
+		}
+
+		if service.Build != nil {
+			if service.Build.Context == "" {
+				service.Build.Context = "."
+			}
+			if service.Image == "" {
+				service.Image = service.Name
+			}
+		}
+
+		service.Labels = service.ContainerLabels()
+	}
+
+	return nil
+}
+
+// Containers lists the containers which belong to the project, including the containers of
+// services which are no longer defined. Stopped containers are only included when all is set.
+func (c Compose) Containers(ctx context.Context, all bool) ([]commands.InspectResult, error) {
+	cmd, err := commands.List()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := cmd.SetAll(all).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []commands.InspectResult
+	for _, result := range results {
+		if result.Configuration.Labels[LabelProject] == c.Name {
+			containers = append(containers, result)
+		}
+	}
+
+	return containers, nil
+}
+
+// normalizeProjectName lowercases the name and drops any character which is not allowed in
+// a project name.
+func normalizeProjectName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return -1
+	}, name)
 }
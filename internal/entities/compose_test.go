@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadOverrideFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		paths []string
+		want  string
+	}{
+		{
+			name: "default file with override",
+			files: map[string]string{
+				"compose.yaml":          "services:\n  web:\n    image: base\n",
+				"compose.override.yaml": "services:\n  web:\n    image: override\n",
+			},
+			want: "override",
+		},
+		{
+			name: "default file without override",
+			files: map[string]string{
+				"compose.yaml": "services:\n  web:\n    image: base\n",
+			},
+			want: "base",
+		},
+		{
+			name: "explicit file ignores the override",
+			files: map[string]string{
+				"compose.yaml":          "services:\n  web:\n    image: base\n",
+				"compose.override.yaml": "services:\n  web:\n    image: override\n",
+			},
+			paths: []string{"compose.yaml"},
+			want:  "base",
+		},
+		{
+			name: "explicit files override each other",
+			files: map[string]string{
+				"compose.yaml":          "services:\n  web:\n    image: base\n",
+				"compose.override.yaml": "services:\n  web:\n    image: override\n",
+				"compose.prod.yaml":     "services:\n  web:\n    image: prod\n",
+			},
+			paths: []string{"compose.yaml", "compose.prod.yaml"},
+			want:  "prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			t.Chdir(dir)
+
+			config, err := Load(tt.paths...)
+			if err != nil {
+				t.Fatalf("Load(%q) returned an error: %v", tt.paths, err)
+			}
+			if got := config.Services["web"].Image; got != tt.want {
+				t.Errorf("Load(%q) image = %q, want %q", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeMarshalVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "without a version", content: "services:\n  web:\n    image: nginx\n"},
+		{name: "with a version", content: "version: \"3.8\"\nservices:\n  web:\n    image: nginx\n", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := yaml.Marshal(parse(t, tt.content))
+			if err != nil {
+				t.Fatalf("marshaling the compose file: %v", err)
+			}
+			if got := strings.Contains(string(data), "version:"); got != tt.want {
+				t.Errorf("marshaled compose file has a version: %v, want %v\n%s", got, tt.want, data)
+			}
+		})
+	}
+}
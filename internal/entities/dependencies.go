@@ -0,0 +1,350 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/container-compose/cli/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConditionStarted waits for the dependency's container to be started
+	ConditionStarted = "service_started"
+	// ConditionHealthy waits for the dependency's healthcheck to pass
+	ConditionHealthy = "service_healthy"
+	// ConditionCompletedSuccessfully waits for the dependency to run to completion and exit with 0
+	ConditionCompletedSuccessfully = "service_completed_successfully"
+)
+
+// Dependency is how a service depends on another service.
+type Dependency struct {
+	Condition string `yaml:"condition"`
+	Required  bool   `yaml:"required"`
+}
+
+// Dependencies are the services a service depends on, keyed by service.
+type Dependencies map[string]*Dependency
+
+// UnmarshalYAML implements custom YAML unmarshaling for Dependencies which handles both a
+// list of service names and a map of services to their condition
+func (d *Dependencies) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var names []string
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+		*d = make(Dependencies, len(names))
+		for _, name := range names {
+			(*d)[name] = &Dependency{Condition: ConditionStarted, Required: true}
+		}
+		return nil
+	}
+
+	if value.Kind == yaml.MappingNode {
+		var entries map[string]*struct {
+			Condition string `yaml:"condition"`
+			Required  *bool  `yaml:"required"`
+		}
+		if err := value.Decode(&entries); err != nil {
+			return err
+		}
+
+		*d = make(Dependencies, len(entries))
+		for name, entry := range entries {
+			dependency := &Dependency{Condition: ConditionStarted, Required: true}
+			if entry != nil {
+				if entry.Condition != "" {
+					dependency.Condition = entry.Condition
+				}
+				if entry.Required != nil {
+					dependency.Required = *entry.Required
+				}
+			}
+
+			switch dependency.Condition {
+			case ConditionStarted, ConditionHealthy, ConditionCompletedSuccessfully:
+			default:
+				return fmt.Errorf("line %d: invalid condition %q for dependency %q, must be one of: %s, %s, %s",
+					value.Line, dependency.Condition, name, ConditionStarted, ConditionHealthy, ConditionCompletedSuccessfully)
+			}
+
+			(*d)[name] = dependency
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: depends_on must be either a list or a map", value.Line)
+}
+
+// MarshalYAML writes the dependencies as a list of names unless one of them has a condition
+// or is optional
+func (d Dependencies) MarshalYAML() (interface{}, error) {
+	for _, dependency := range d {
+		if dependency.Condition != ConditionStarted || !dependency.Required {
+			return map[string]*Dependency(d), nil
+		}
+	}
+	return d.Names(), nil
+}
+
+// Names returns the names of the services depended on, sorted.
+func (d Dependencies) Names() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Order returns every service in the compose file sorted so that each service comes after
+// the services it depends on. Services without a dependency between them are sorted by
+// name so that the order is the same on every run.
+func (c Compose) Order() ([]*Service, error) {
+	names := make([]string, 0, len(c.Services))
+	for name := range c.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ordered := make([]*Service, 0, len(names))
+	visited := make(map[string]bool)
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+
+		// if the service is already on the path we have walked around a cycle
+		if i := slices.Index(path, name); i >= 0 {
+			cycle := append(slices.Clone(path[i:]), name)
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+
+		path = append(path, name)
+		dependsOn := c.Services[name].DependsOn
+		for _, dependency := range dependsOn.Names() {
+			if _, ok := c.Services[dependency]; !ok {
+				if !dependsOn[dependency].Required {
+					continue
+				}
+				if c.IsDefined(dependency) {
+					return fmt.Errorf("service %q depends on service %q, whose profiles are not enabled", name, dependency)
+				}
+				return fmt.Errorf("service %q depends on undefined service %q", name, dependency)
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[name] = true
+		ordered = append(ordered, c.Services[name])
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// Select returns the named services in dependency order. When no names are given every
+// service is returned.
+func (c Compose) Select(names ...string) ([]*Service, error) {
+	for _, name := range names {
+		if _, ok := c.Services[name]; !ok {
+			return nil, fmt.Errorf("no such service: %s", name)
+		}
+	}
+
+	ordered, err := c.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return ordered, nil
+	}
+
+	selected := make([]*Service, 0, len(names))
+	for _, service := range ordered {
+		if slices.Contains(names, service.ServiceName) {
+			selected = append(selected, service)
+		}
+	}
+
+	return selected, nil
+}
+
+// Dependencies returns the services the named service depends on, directly or through other
+// services, in dependency order.
+func (c Compose) Dependencies(name string) ([]*Service, error) {
+	if _, ok := c.Services[name]; !ok {
+		return nil, fmt.Errorf("no such service: %s", name)
+	}
+
+	// walk the graph to find everything the service needs
+	needed := make(map[string]bool)
+	pending := c.Services[name].DependsOn.Names()
+	for len(pending) > 0 {
+		dependency := pending[0]
+		pending = pending[1:]
+		if needed[dependency] {
+			continue
+		}
+		needed[dependency] = true
+		if service, ok := c.Services[dependency]; ok {
+			pending = append(pending, service.DependsOn.Names()...)
+		}
+	}
+
+	ordered, err := c.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []*Service
+	for _, service := range ordered {
+		if needed[service.ServiceName] && service.ServiceName != name {
+			dependencies = append(dependencies, service)
+		}
+	}
+
+	return dependencies, nil
+}
+
+// Up starts the services in the order given, which should be dependency order. Before a
+// service is started each of its dependencies has to meet its condition; a service whose
+// required dependencies do not is skipped. Services which others wait on to complete are run
+// in the foreground until they exit. Failures are logged and reported together at the end.
+func (c Compose) Up(ctx context.Context, services []*Service) error {
+	logger := logger.FromContext(ctx)
+
+	// work out which services have to run to completion
+	complete := make(map[string]bool)
+	for _, service := range c.Services {
+		for name, dependency := range service.DependsOn {
+			if dependency.Condition == ConditionCompletedSuccessfully {
+				complete[name] = true
+			}
+		}
+	}
+
+	outcomes := make(map[string]error)
+	failed := 0
+	for _, service := range services {
+		if err := c.waitForDependencies(ctx, service, outcomes); err != nil {
+			logger.ErrorContext(ctx, "dependencies not met", "service", service.ServiceName, "error", err)
+			outcomes[service.ServiceName] = err
+			failed++
+			continue
+		}
+
+		var err error
+		if complete[service.ServiceName] {
+			logger.InfoContext(ctx, "running service to completion", "service", service.ServiceName)
+			if err = service.Complete(ctx); err == nil {
+				logger.InfoContext(ctx, "service completed", "service", service.ServiceName, "name", service.Name)
+			}
+		} else {
+			var started bool
+			if started, err = service.Up(ctx); err == nil && started {
+				logger.InfoContext(ctx, "started service", "service", service.ServiceName, "name", service.Name)
+			}
+		}
+
+		outcomes[service.ServiceName] = err
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to start service", "service", service.ServiceName, "error", err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to start %d service(s)", failed)
+	}
+
+	return nil
+}
+
+// WaitForDependencies waits until every dependency of the service meets its condition. The
+// dependencies must already have been brought up by Up.
+func (c Compose) WaitForDependencies(ctx context.Context, service *Service) error {
+	outcomes := make(map[string]error)
+	for name := range service.DependsOn {
+		outcomes[name] = nil
+	}
+	return c.waitForDependencies(ctx, service, outcomes)
+}
+
+// waitForDependencies waits until every dependency of the service meets its condition.
+// Outcomes holds the result of starting the services handled so far. Optional dependencies
+// which do not meet their condition are only warned about.
+func (c Compose) waitForDependencies(ctx context.Context, service *Service, outcomes map[string]error) error {
+	for _, name := range service.DependsOn.Names() {
+		dependency := service.DependsOn[name]
+
+		err := c.waitForDependency(ctx, name, dependency.Condition, outcomes)
+		if err != nil && !dependency.Required {
+			logger.FromContext(ctx).WarnContext(ctx, "optional dependency not met", "service", service.ServiceName, "dependency", name, "error", err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitForDependency waits until the named service meets the condition.
+func (c Compose) waitForDependency(ctx context.Context, name, condition string, outcomes map[string]error) error {
+	dependency, ok := c.Services[name]
+	if !ok {
+		return fmt.Errorf("service %q is not defined", name)
+	}
+
+	outcome, handled := outcomes[name]
+	if handled && outcome != nil {
+		return fmt.Errorf("dependency %q failed: %w", name, outcome)
+	}
+
+	switch condition {
+	case ConditionCompletedSuccessfully:
+		if !handled {
+			return fmt.Errorf("dependency %q has not been run to completion", name)
+		}
+		return nil
+
+	case ConditionHealthy:
+		if !dependency.Healthcheck.Enabled() {
+			return fmt.Errorf("dependency %q has no healthcheck to wait for", name)
+		}
+		logger.FromContext(ctx).InfoContext(ctx, "waiting for service to be healthy", "service", name)
+		return dependency.WaitHealthy(ctx)
+
+	default:
+		if handled {
+			return nil
+		}
+		isRunning, err := dependency.IsRunning(ctx)
+		if err != nil {
+			return err
+		}
+		if !isRunning {
+			return fmt.Errorf("dependency %q is not running", name)
+		}
+		return nil
+	}
+}
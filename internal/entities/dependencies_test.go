@@ -0,0 +1,298 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/container-compose/cli/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// names returns the names of the services, in order.
+func names(services []*Service) []string {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.ServiceName)
+	}
+	return names
+}
+
+func TestDependenciesUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    Dependencies
+		wantErr bool
+	}{
+		{
+			name: "list",
+			yaml: "[db, cache]",
+			want: Dependencies{
+				"db":    {Condition: ConditionStarted, Required: true},
+				"cache": {Condition: ConditionStarted, Required: true},
+			},
+		},
+		{
+			name: "map",
+			yaml: "{db: {condition: service_healthy}, migrate: {condition: service_completed_successfully}, cache: {required: false}, queue: null}",
+			want: Dependencies{
+				"db":      {Condition: ConditionHealthy, Required: true},
+				"migrate": {Condition: ConditionCompletedSuccessfully, Required: true},
+				"cache":   {Condition: ConditionStarted, Required: false},
+				"queue":   {Condition: ConditionStarted, Required: true},
+			},
+		},
+		{name: "invalid condition", yaml: "{db: {condition: service_ready}}", wantErr: true},
+		{name: "string", yaml: "db", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Dependencies
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if !slices.Equal(got.Names(), tt.want.Names()) {
+				t.Fatalf("unmarshaling %s gave %v, want %v", tt.yaml, got.Names(), tt.want.Names())
+			}
+			for name, want := range tt.want {
+				if *got[name] != *want {
+					t.Errorf("dependency %s = %+v, want %+v", name, *got[name], *want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "dependencies first",
+			content: `
+services:
+  web:
+    depends_on: [api]
+  api:
+    depends_on: [db, cache]
+  db: {}
+  cache: {}
+  worker:
+    depends_on: [db]`,
+			want: []string{"cache", "db", "api", "web", "worker"},
+		},
+		{
+			name: "optional dependency which is not defined",
+			content: `
+services:
+  web:
+    depends_on:
+      metrics:
+        required: false`,
+			want: []string{"web"},
+		},
+		{
+			name: "required dependency which is not defined",
+			content: `
+services:
+  web:
+    depends_on: [db]`,
+			wantErr: `service "web" depends on undefined service "db"`,
+		},
+		{
+			name: "cycle",
+			content: `
+services:
+  a:
+    depends_on: [b]
+  b:
+    depends_on: [c]
+  c:
+    depends_on: [a]`,
+			wantErr: "dependency cycle detected: a -> b -> c -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := parse(t, tt.content).Order()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Order returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Order returned an error: %v", err)
+			}
+			if got := names(ordered); !slices.Equal(got, tt.want) {
+				t.Errorf("Order = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+const dependencyChain = `
+services:
+  web:
+    depends_on: [api]
+  api:
+    depends_on: [db]
+  db: {}
+  worker: {}`
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []string
+		want     []string
+		wantErr  string
+	}{
+		{name: "every service", want: []string{"db", "api", "web", "worker"}},
+		{name: "in dependency order", services: []string{"web", "db"}, want: []string{"db", "web"}},
+		{name: "without their dependencies", services: []string{"web"}, want: []string{"web"}},
+		{name: "undefined service", services: []string{"admin"}, wantErr: "no such service: admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := parse(t, dependencyChain).Select(tt.services...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Select(%q) returned %v, want an error containing %q", tt.services, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select(%q) returned an error: %v", tt.services, err)
+			}
+			if got := names(selected); !slices.Equal(got, tt.want) {
+				t.Errorf("Select(%q) = %q, want %q", tt.services, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	tests := []struct {
+		service string
+		want    []string
+		wantErr string
+	}{
+		{service: "web", want: []string{"db", "api"}},
+		{service: "api", want: []string{"db"}},
+		{service: "worker", want: nil},
+		{service: "admin", wantErr: "no such service: admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			dependencies, err := parse(t, dependencyChain).Dependencies(tt.service)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Dependencies(%q) returned %v, want an error containing %q", tt.service, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Dependencies(%q) returned an error: %v", tt.service, err)
+			}
+			if got := names(dependencies); !slices.Equal(got, tt.want) {
+				t.Errorf("Dependencies(%q) = %q, want %q", tt.service, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	ctx, _ := logger.New(context.Background(), io.Discard, slog.LevelInfo)
+	failed := errors.New("exited with 1")
+
+	tests := []struct {
+		name     string
+		content  string
+		outcomes map[string]error
+		wantErr  string
+	}{
+		{
+			name: "required dependency completed",
+			content: `
+services:
+  web:
+    depends_on:
+      migrate:
+        condition: service_completed_successfully
+  migrate: {}`,
+			outcomes: map[string]error{"migrate": nil},
+		},
+		{
+			name: "required dependency failed",
+			content: `
+services:
+  web:
+    depends_on:
+      migrate:
+        condition: service_completed_successfully
+  migrate: {}`,
+			outcomes: map[string]error{"migrate": failed},
+			wantErr:  `dependency "migrate" failed: exited with 1`,
+		},
+		{
+			name: "required dependency not run",
+			content: `
+services:
+  web:
+    depends_on:
+      migrate:
+        condition: service_completed_successfully
+  migrate: {}`,
+			outcomes: map[string]error{},
+			wantErr:  `dependency "migrate" has not been run to completion`,
+		},
+		{
+			name: "optional dependency failed",
+			content: `
+services:
+  web:
+    depends_on:
+      migrate:
+        condition: service_completed_successfully
+        required: false
+  migrate: {}`,
+			outcomes: map[string]error{"migrate": failed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, tt.content)
+
+			err := config.waitForDependencies(ctx, config.Services["web"], tt.outcomes)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("waitForDependencies returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("waitForDependencies returned an error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseDotEnv parses the contents of a .env file. Each line is KEY=VALUE, optionally preceded
+// by export, and blank lines and lines starting with # are ignored. Values may be:
+//
+//   - unquoted, where a # after whitespace starts a comment
+//   - single quoted, which are taken literally
+//   - double quoted, which understand \n, \r, \t, \\, \" and \$ escapes
+//
+// Quoted values may span several lines. Variables are substituted into unquoted and double
+// quoted values from the variables defined above them, and otherwise from lookup. A key
+// without a value takes its value from lookup, and is left out when lookup does not have it.
+func ParseDotEnv(content []byte, lookup func(string) (string, bool)) (map[string]string, error) {
+	variables := make(map[string]string)
+	resolve := func(name string) (string, bool) {
+		if value, ok := variables[name]; ok {
+			return value, true
+		}
+		return lookup(name)
+	}
+
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	line := 1
+	for len(text) > 0 {
+		// take the next line, leaving the rest for quoted values which carry on past it
+		current, rest, _ := strings.Cut(text, "\n")
+		start := line
+		text = rest
+		line++
+
+		current = strings.TrimSpace(current)
+		if current == "" || strings.HasPrefix(current, "#") {
+			continue
+		}
+		current = strings.TrimPrefix(current, "export ")
+
+		key, value, hasValue := strings.Cut(current, "=")
+		key = strings.TrimSpace(key)
+		if !isDotEnvKey(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", start, key)
+		}
+
+		if !hasValue {
+			if value, ok := lookup(key); ok {
+				variables[key] = value
+			}
+			continue
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		switch {
+		case strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`):
+			quote := value[0]
+
+			// find the closing quote, reading further lines until there is one
+			body := value[1:]
+			end := closingQuote(body, quote)
+			for end < 0 && len(text) > 0 {
+				next, rest, _ := strings.Cut(text, "\n")
+				body += "\n" + next
+				text = rest
+				line++
+				end = closingQuote(body, quote)
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for %s", start, key)
+			}
+
+			trailing := strings.TrimSpace(body[end+1:])
+			if trailing != "" && !strings.HasPrefix(trailing, "#") {
+				return nil, fmt.Errorf("line %d: unexpected characters after the quoted value for %s", start, key)
+			}
+
+			body = body[:end]
+			if quote == '"' {
+				substituted, err := Substitute(unescape(body), resolve)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", start, err)
+				}
+				body = substituted
+			}
+			variables[key] = body
+
+		default:
+			// a comment has to be separated from the value by whitespace
+			for i := 1; i < len(value); i++ {
+				if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+					value = value[:i]
+					break
+				}
+			}
+			substituted, err := Substitute(strings.TrimSpace(value), resolve)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			variables[key] = substituted
+		}
+	}
+
+	return variables, nil
+}
+
+// closingQuote returns the index of the quote which ends the value, or -1 when there is none.
+// Double quotes may be escaped with a backslash.
+func closingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescape replaces the escapes understood in double quoted values. An escaped $ is written
+// as $$ so that it survives substitution.
+func unescape(value string) string {
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		switch value[i] {
+		case 'n':
+			result.WriteByte('\n')
+		case 'r':
+			result.WriteByte('\r')
+		case 't':
+			result.WriteByte('\t')
+		case '$':
+			result.WriteString("$$")
+		case '\\', '"':
+			result.WriteByte(value[i])
+		default:
+			result.WriteByte('\\')
+			result.WriteByte(value[i])
+		}
+	}
+	return result.String()
+}
+
+// isDotEnvKey reports whether the key is a valid variable name.
+func isDotEnvKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if !isNameChar(key[i]) && key[i] != '.' && key[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseRawEnv parses the contents of an env file in the raw format, where every line is
+// KEY=VALUE taken literally: there is no quoting, no comments after a value and no
+// substitution.
+func parseRawEnv(content []byte) (map[string]string, error) {
+	variables := make(map[string]string)
+	for i, line := range strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !isDotEnvKey(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", i+1, key)
+		}
+		variables[key] = value
+	}
+	return variables, nil
+}
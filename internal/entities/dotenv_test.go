@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	lookup := Environment{"HOME": "/home/user", "FROM_PROCESS": "process"}.Lookup
+
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "plain values",
+			content: "A=1\nB = two \n\n# a comment\n  # an indented comment\nexport C=3\n",
+			want:    map[string]string{"A": "1", "B": "two", "C": "3"},
+		},
+		{
+			name:    "windows line endings",
+			content: "A=1\r\nB=2\r\n",
+			want:    map[string]string{"A": "1", "B": "2"},
+		},
+		{
+			name:    "empty value",
+			content: "EMPTY=\n",
+			want:    map[string]string{"EMPTY": ""},
+		},
+		{
+			name:    "comments after unquoted values",
+			content: "A=value # comment\nB=value#not-a-comment\nC=#not-a-comment\n",
+			want:    map[string]string{"A": "value", "B": "value#not-a-comment", "C": "#not-a-comment"},
+		},
+		{
+			name:    "single quoted values are literal",
+			content: `A='$HOME \n # not a comment' # comment` + "\n",
+			want:    map[string]string{"A": `$HOME \n # not a comment`},
+		},
+		{
+			name:    "double quoted values understand escapes",
+			content: `A="line\nnext\ttab \"quoted\" \\ \$HOME $HOME"` + "\n",
+			want:    map[string]string{"A": "line\nnext\ttab \"quoted\" \\ $HOME /home/user"},
+		},
+		{
+			name:    "quoted values span lines",
+			content: "A=\"first\nsecond\"\nB='one\ntwo'\nC=after\n",
+			want:    map[string]string{"A": "first\nsecond", "B": "one\ntwo", "C": "after"},
+		},
+		{
+			name:    "variables defined above are substituted",
+			content: "BASE=/srv\nDATA=${BASE}/data\nLOGS=\"$BASE/logs\"\nMISSING=${NOPE:-fallback}\n",
+			want:    map[string]string{"BASE": "/srv", "DATA": "/srv/data", "LOGS": "/srv/logs", "MISSING": "fallback"},
+		},
+		{
+			name:    "variables fall back to lookup",
+			content: "DIR=$HOME/app\n",
+			want:    map[string]string{"DIR": "/home/user/app"},
+		},
+		{
+			name:    "keys without a value come from lookup",
+			content: "FROM_PROCESS\nNOT_SET\n",
+			want:    map[string]string{"FROM_PROCESS": "process"},
+		},
+		{
+			name:    "keys may contain dots and dashes",
+			content: "app.name=web\nlog-level=debug\n",
+			want:    map[string]string{"app.name": "web", "log-level": "debug"},
+		},
+		{
+			name:    "invalid key",
+			content: "NOT VALID=1\n",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			content: "A=\"never closed\nB=2\n",
+			wantErr: true,
+		},
+		{
+			name:    "text after a quoted value",
+			content: "A='value' trailing\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing required variable",
+			content: "A=${NOPE:?is required}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDotEnv([]byte(tt.content), lookup)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDotEnv(%q) = %v, want an error", tt.content, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDotEnv(%q) returned an error: %v", tt.content, err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("ParseDotEnv(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
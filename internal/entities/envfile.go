@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvFile is a file of environment variables set in a service's containers.
+type EnvFile struct {
+	Path     string `yaml:"path"`
+	Required bool   `yaml:"required"`
+	Format   string `yaml:"format,omitempty"`
+}
+
+// EnvFiles are the env files of a service, in the order they are applied.
+type EnvFiles []EnvFile
+
+// UnmarshalYAML implements custom YAML unmarshaling for EnvFiles which handles a single path,
+// a list of paths and a list of entries with a path, whether it is required and its format
+func (e *EnvFiles) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*e = EnvFiles{{Path: value.Value, Required: true}}
+		return nil
+	}
+
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: env_file must be either a string or a list", value.Line)
+	}
+
+	files := make(EnvFiles, 0, len(value.Content))
+	for _, item := range value.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			files = append(files, EnvFile{Path: item.Value, Required: true})
+
+		case yaml.MappingNode:
+			var entry struct {
+				Path     string `yaml:"path"`
+				Required *bool  `yaml:"required"`
+				Format   string `yaml:"format"`
+			}
+			if err := item.Decode(&entry); err != nil {
+				return err
+			}
+			if entry.Path == "" {
+				return fmt.Errorf("line %d: invalid env_file: path is required", item.Line)
+			}
+			if entry.Format != "" && entry.Format != "raw" {
+				return fmt.Errorf("line %d: invalid env_file format %q, must be raw or left out", item.Line, entry.Format)
+			}
+
+			file := EnvFile{Path: entry.Path, Required: true, Format: entry.Format}
+			if entry.Required != nil {
+				file.Required = *entry.Required
+			}
+			files = append(files, file)
+
+		default:
+			return fmt.Errorf("line %d: env_file entry must be either a string or an object", item.Line)
+		}
+	}
+
+	*e = files
+	return nil
+}
+
+// ResolveEnvFiles reads the env files of every service into its environment. Files later in
+// the list override earlier ones and the service's own environment overrides them all.
+// Variables are substituted into the files from lookup. Once read the env files are dropped
+// from the service, as their contents are part of its environment.
+func (c *Compose) ResolveEnvFiles(lookup func(string) (string, bool)) error {
+	for _, service := range c.Services {
+		if len(service.EnvFile) == 0 {
+			continue
+		}
+
+		environment := make(map[string]string)
+		for _, file := range service.EnvFile {
+			variables, err := file.Read(lookup)
+			if err != nil {
+				return fmt.Errorf("service %q: %w", service.ServiceName, err)
+			}
+			for key, value := range variables {
+				environment[key] = value
+			}
+		}
+
+		for key, value := range service.EnvironmentVariables {
+			environment[key] = value
+		}
+
+		service.EnvironmentVariables = environment
+		service.EnvFile = nil
+	}
+
+	return nil
+}
+
+// Read parses the variables in the file. An optional file which does not exist has none.
+func (f EnvFile) Read(lookup func(string) (string, bool)) (map[string]string, error) {
+	contents, err := os.ReadFile(f.Path)
+	if os.IsNotExist(err) && !f.Required {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var variables map[string]string
+	if f.Format == "raw" {
+		variables, err = parseRawEnv(contents)
+	} else {
+		variables, err = ParseDotEnv(contents, lookup)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Path, err)
+	}
+
+	return variables, nil
+}
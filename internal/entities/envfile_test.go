@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeFiles writes the files, keyed by their path relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEnvFilesUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    EnvFiles
+		wantErr bool
+	}{
+		{
+			name: "single path",
+			yaml: "app.env",
+			want: EnvFiles{{Path: "app.env", Required: true}},
+		},
+		{
+			name: "list of paths",
+			yaml: "[a.env, b.env]",
+			want: EnvFiles{{Path: "a.env", Required: true}, {Path: "b.env", Required: true}},
+		},
+		{
+			name: "entries",
+			yaml: "[{path: a.env}, {path: b.env, required: false}, {path: c.env, format: raw}]",
+			want: EnvFiles{
+				{Path: "a.env", Required: true},
+				{Path: "b.env", Required: false},
+				{Path: "c.env", Required: true, Format: "raw"},
+			},
+		},
+		{
+			name:    "entry without a path",
+			yaml:    "[{required: false}]",
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			yaml:    "[{path: a.env, format: json}]",
+			wantErr: true,
+		},
+		{
+			name:    "map",
+			yaml:    "{path: a.env}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got EnvFiles
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unmarshaling %s gave %v, want %v", tt.yaml, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base.env":     "A=base\nB=base\nC=${SUBSTITUTED}\n",
+		"override.env": "B=override\nD=override\n",
+		"raw.env":      "RAW=${NOT_SUBSTITUTED} # kept\n",
+	})
+	lookup := Environment{"SUBSTITUTED": "substituted"}.Lookup
+
+	tests := []struct {
+		name        string
+		files       EnvFiles
+		environment EnvironmentVariables
+		want        map[string]string
+		wantErr     bool
+	}{
+		{
+			name:  "later files override earlier ones",
+			files: EnvFiles{{Path: "base.env", Required: true}, {Path: "override.env", Required: true}},
+			want:  map[string]string{"A": "base", "B": "override", "C": "substituted", "D": "override"},
+		},
+		{
+			name:        "the service's environment overrides the files",
+			files:       EnvFiles{{Path: "base.env", Required: true}},
+			environment: EnvironmentVariables{"A": "service"},
+			want:        map[string]string{"A": "service", "B": "base", "C": "substituted"},
+		},
+		{
+			name:  "raw files are taken literally",
+			files: EnvFiles{{Path: "raw.env", Required: true, Format: "raw"}},
+			want:  map[string]string{"RAW": "${NOT_SUBSTITUTED} # kept"},
+		},
+		{
+			name:  "missing optional files are skipped",
+			files: EnvFiles{{Path: "missing.env"}, {Path: "override.env", Required: true}},
+			want:  map[string]string{"B": "override", "D": "override"},
+		},
+		{
+			name:    "missing required files are an error",
+			files:   EnvFiles{{Path: "missing.env", Required: true}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := make(EnvFiles, len(tt.files))
+			for i, file := range tt.files {
+				file.Path = filepath.Join(dir, file.Path)
+				files[i] = file
+			}
+			config := Compose{Services: map[string]*Service{
+				"web": {ServiceName: "web", EnvFile: files, EnvironmentVariables: tt.environment},
+			}}
+
+			err := config.ResolveEnvFiles(lookup)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveEnvFiles succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveEnvFiles returned an error: %v", err)
+			}
+
+			web := config.Services["web"]
+			if !maps.Equal(web.EnvironmentVariables, tt.want) {
+				t.Errorf("environment = %v, want %v", web.EnvironmentVariables, tt.want)
+			}
+			if web.EnvFile != nil {
+				t.Errorf("env_file = %v, want it dropped once read", web.EnvFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Extends names the service a service inherits its configuration from, optionally in
+// another compose file.
+type Extends struct {
+	Service string `yaml:"service"`
+	File    string `yaml:"file,omitempty"`
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for Extends which handles both the name
+// of a service in the same file and an object with the service and the file it is in
+func (e *Extends) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Service = value.Value
+		return nil
+	}
+
+	if value.Kind == yaml.MappingNode {
+		type extendsAlias Extends
+		aux := (*extendsAlias)(e)
+		if err := value.Decode(aux); err != nil {
+			return err
+		}
+		if e.Service == "" {
+			return fmt.Errorf("line %d: invalid extends: service is required", value.Line)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: extends must be either a string or an object", value.Line)
+}
+
+// ResolveExtends replaces every service which extends another with the service it extends
+// merged with its own configuration. Extends may be chained, and a service in another file
+// has its relative paths made relative to that file. path is the file the compose file was
+// read from, and variables are substituted into other files from lookup.
+func (c *Compose) ResolveExtends(path string, lookup func(string) (string, bool)) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	resolver := &extendsResolver{
+		root:     path,
+		files:    map[string]*Compose{path: c},
+		resolved: make(map[string]*Service),
+		lookup:   lookup,
+	}
+
+	for _, name := range sortedKeys(c.Services) {
+		if c.Services[name].Extends == nil {
+			continue
+		}
+		service, err := resolver.resolve(path, name, nil)
+		if err != nil {
+			return err
+		}
+		c.Services[name] = service
+	}
+
+	return nil
+}
+
+// extendsResolver resolves the services which extend others, keeping the files it has read
+// and the services it has resolved so that each is only done once.
+type extendsResolver struct {
+	root     string
+	files    map[string]*Compose
+	resolved map[string]*Service
+	lookup   func(string) (string, bool)
+}
+
+// resolve returns the named service in the file with everything it extends merged in. chain
+// holds the keys, path:name, of the services being resolved which led to this one.
+func (r *extendsResolver) resolve(path, name string, chain []string) (*Service, error) {
+	key := path + ":" + name
+	if service, ok := r.resolved[key]; ok {
+		return service, nil
+	}
+
+	// if the service is already in the chain we have walked around a cycle
+	if i := slices.Index(chain, key); i >= 0 {
+		var cycle []string
+		for _, link := range append(slices.Clone(chain[i:]), key) {
+			cycle = append(cycle, r.describe(link))
+		}
+		return nil, fmt.Errorf("extends cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	file, err := r.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	service, ok := file.Services[name]
+	if !ok {
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("no such service: %s", r.describe(key))
+		}
+		return nil, fmt.Errorf("service %s extends undefined service %s", r.describe(chain[len(chain)-1]), r.describe(key))
+	}
+
+	if service.Extends == nil {
+		r.resolved[key] = service
+		return service, nil
+	}
+
+	// the file of the extended service is relative to the file which names it
+	basePath := path
+	if service.Extends.File != "" {
+		basePath, err = resolvePath(service.Extends.File, filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	base, err := r.resolve(basePath, service.Extends.Service, append(chain, key))
+	if err != nil {
+		return nil, err
+	}
+
+	// merge into a new service so that the extended service is left as it is
+	own := *service
+	own.Extends = nil
+	merged := &Service{
+		ServiceName: service.ServiceName,
+		Project:     service.Project,
+	}
+	merged.Merge(base)
+	merged.Merge(&own)
+
+	r.resolved[key] = merged
+	return merged, nil
+}
+
+// load returns the compose file at path, reading it the first time it is needed. The paths
+// in files other than the one being resolved are made absolute, as they are relative to the
+// file they are in.
+func (r *extendsResolver) load(path string) (*Compose, error) {
+	if file, ok := r.files[path]; ok {
+		return file, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := Parse(contents, r.lookup)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := file.ResolvePaths(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	r.files[path] = &file
+	return &file, nil
+}
+
+// describe names the service with the given key for messages, including the file when it is
+// not the one being resolved.
+func (r *extendsResolver) describe(key string) string {
+	i := strings.LastIndex(key, ":")
+	path, name := key[:i], key[i+1:]
+	if path == r.root {
+		return name
+	}
+	return filepath.Base(path) + ":" + name
+}
+
+// sortedKeys returns the keys of the services, sorted.
+func sortedKeys(services map[string]*Service) []string {
+	keys := make([]string, 0, len(services))
+	for key := range services {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
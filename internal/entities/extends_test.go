@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveExtends(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		check   func(t *testing.T, dir string, config Compose)
+		wantErr string
+	}{
+		{
+			name: "same file",
+			files: map[string]string{
+				"compose.yaml": `
+services:
+  base:
+    image: app
+    environment: {A: base, B: base}
+  web:
+    extends: base
+    environment: {B: web}`,
+			},
+			check: func(t *testing.T, dir string, config Compose) {
+				web := config.Services["web"]
+				if web.Image != "app" {
+					t.Errorf("image = %q, want app", web.Image)
+				}
+				if web.EnvironmentVariables["A"] != "base" || web.EnvironmentVariables["B"] != "web" {
+					t.Errorf("environment = %v, want A=base and B=web", web.EnvironmentVariables)
+				}
+				if web.Extends != nil {
+					t.Errorf("extends = %+v, want it resolved", web.Extends)
+				}
+				if base := config.Services["base"]; base.EnvironmentVariables["B"] != "base" {
+					t.Errorf("base environment = %v, want the extended service left as it is", base.EnvironmentVariables)
+				}
+			},
+		},
+		{
+			name: "chained",
+			files: map[string]string{
+				"compose.yaml": `
+services:
+  web:
+    extends: {service: middle}
+    environment: {C: web}
+  middle:
+    extends: root
+    environment: {B: middle}
+  root:
+    image: root
+    environment: {A: root, B: root}`,
+			},
+			check: func(t *testing.T, dir string, config Compose) {
+				web := config.Services["web"]
+				env := web.EnvironmentVariables
+				if web.Image != "root" || env["A"] != "root" || env["B"] != "middle" || env["C"] != "web" {
+					t.Errorf("web = image %q, environment %v, want root with A=root, B=middle and C=web", web.Image, env)
+				}
+			},
+		},
+		{
+			name: "another file",
+			files: map[string]string{
+				"compose.yaml": `
+services:
+  web:
+    extends:
+      file: common/services.yaml
+      service: app
+    volumes: ["./own:/own"]`,
+				"common/services.yaml": `
+services:
+  app:
+    image: app
+    volumes: ["./shared:/shared"]`,
+			},
+			check: func(t *testing.T, dir string, config Compose) {
+				web := config.Services["web"]
+				if web.Image != "app" {
+					t.Errorf("image = %q, want app", web.Image)
+				}
+				want := map[string]string{
+					"/shared": filepath.Join(dir, "common", "shared"),
+					"/own":    filepath.Join(dir, "own"),
+				}
+				if len(web.Volumes) != len(want) {
+					t.Fatalf("volumes = %v, want %d", web.Volumes, len(want))
+				}
+				for _, mount := range web.Volumes {
+					if mount.Source != want[mount.Target] {
+						t.Errorf("volume %s source = %q, want %q", mount.Target, mount.Source, want[mount.Target])
+					}
+				}
+			},
+		},
+		{
+			name: "undefined service",
+			files: map[string]string{
+				"compose.yaml": `
+services:
+  web:
+    extends: missing`,
+			},
+			wantErr: "service web extends undefined service missing",
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"compose.yaml": `
+services:
+  a:
+    extends: b
+  b:
+    extends: a`,
+			},
+			wantErr: "extends cycle detected: a -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			config, err := Load(filepath.Join(dir, "compose.yaml"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load returned an error: %v", err)
+			}
+			tt.check(t, dir, config)
+		})
+	}
+}
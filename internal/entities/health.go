@@ -0,0 +1,261 @@
+package entities
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/container-compose/cli/internal/commands"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+	HealthUnknown   = "unknown"
+)
+
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 30 * time.Second
+	defaultHealthRetries  = 3
+
+	// healthRetryDelay is the pause between the probes HealthStatus takes
+	healthRetryDelay = time.Second
+)
+
+// Duration is a duration written the way compose files write them, e.g. 1m30s.
+type Duration time.Duration
+
+// UnmarshalYAML implements custom YAML unmarshaling for Duration
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	parsed, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid duration %q", value.Line, value.Value)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalYAML writes the duration the way compose files write them
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+// HealthcheckTest is the probe a healthcheck runs, in the form ["CMD", args...],
+// ["CMD-SHELL", command] or ["NONE"].
+type HealthcheckTest []string
+
+// UnmarshalYAML implements custom YAML unmarshaling for HealthcheckTest which handles both
+// a list and a string, which is run by the shell
+func (t *HealthcheckTest) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*t = HealthcheckTest{"CMD-SHELL", value.Value}
+		return nil
+	}
+
+	var test []string
+	if err := value.Decode(&test); err != nil {
+		return err
+	}
+
+	if len(test) > 0 {
+		switch test[0] {
+		case "CMD", "CMD-SHELL":
+			if len(test) < 2 {
+				return fmt.Errorf("line %d: healthcheck test %s needs a command", value.Line, test[0])
+			}
+		case "NONE":
+		default:
+			return fmt.Errorf("line %d: healthcheck test must start with CMD, CMD-SHELL or NONE", value.Line)
+		}
+	}
+
+	*t = test
+	return nil
+}
+
+// Healthcheck is the probe which decides whether a service is healthy. The container engine
+// has no healthchecks of its own, so the probe is run inside the container with exec.
+type Healthcheck struct {
+	Test        HealthcheckTest `yaml:"test,omitempty"`
+	Interval    *Duration       `yaml:"interval,omitempty"`
+	Timeout     *Duration       `yaml:"timeout,omitempty"`
+	Retries     *int            `yaml:"retries,omitempty"`
+	StartPeriod *Duration       `yaml:"start_period,omitempty"`
+	Disable     *bool           `yaml:"disable,omitempty"`
+}
+
+// Enabled reports whether the healthcheck has a probe to run.
+func (h *Healthcheck) Enabled() bool {
+	if h == nil || isTrue(h.Disable) || len(h.Test) == 0 {
+		return false
+	}
+	return h.Test[0] != "NONE"
+}
+
+// Command returns the command the probe runs.
+func (h *Healthcheck) Command() []string {
+	if h.Test[0] == "CMD-SHELL" {
+		return []string{"/bin/sh", "-c", h.Test[1]}
+	}
+	return h.Test[1:]
+}
+
+func (h *Healthcheck) interval() time.Duration {
+	if h.Interval == nil {
+		return defaultHealthInterval
+	}
+	return time.Duration(*h.Interval)
+}
+
+func (h *Healthcheck) timeout() time.Duration {
+	if h.Timeout == nil {
+		return defaultHealthTimeout
+	}
+	return time.Duration(*h.Timeout)
+}
+
+func (h *Healthcheck) retries() int {
+	if h.Retries == nil {
+		return defaultHealthRetries
+	}
+	return *h.Retries
+}
+
+func (h *Healthcheck) startPeriod() time.Duration {
+	if h.StartPeriod == nil {
+		return 0
+	}
+	return time.Duration(*h.StartPeriod)
+}
+
+// Probe runs the probe once inside the container and returns an error when it fails.
+func (h *Healthcheck) Probe(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout())
+	defer cancel()
+
+	cmd, err := commands.Exec(id, h.Command())
+	if err != nil {
+		return err
+	}
+
+	output := &bytes.Buffer{}
+	code, err := cmd.SetOutput(output).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("probe did not finish: %w", err)
+	}
+	if code != 0 {
+		return fmt.Errorf("probe exited with %d: %s", code, bytes.TrimSpace(output.Bytes()))
+	}
+
+	return nil
+}
+
+// Health tracks the health of a container from the results of its probes. Failures during
+// the start period do not count, after that the container is unhealthy once the probe has
+// failed retries times in a row.
+type Health struct {
+	Status        string
+	FailingStreak int
+	LastError     error
+
+	check   *Healthcheck
+	started time.Time
+}
+
+// NewHealth starts tracking the health of a container started at the given time.
+func NewHealth(check *Healthcheck, started time.Time) *Health {
+	return &Health{
+		Status:  HealthStarting,
+		check:   check,
+		started: started,
+	}
+}
+
+// Record updates the health with the result of a probe taken at the given time.
+func (h *Health) Record(result error, at time.Time) {
+	h.LastError = result
+	if result == nil {
+		h.Status = HealthHealthy
+		h.FailingStreak = 0
+		return
+	}
+
+	if h.Status == HealthStarting && at.Sub(h.started) < h.check.startPeriod() {
+		return
+	}
+
+	h.FailingStreak++
+	if h.FailingStreak >= h.check.retries() {
+		h.Status = HealthUnhealthy
+	}
+}
+
+// HealthStatus probes the container and reports its health. Nothing keeps a record of
+// earlier probes, so a failed probe is retried straight away and the container is only
+// unhealthy once it has failed retries times. Failures during the start period mean it is
+// still starting. When the context is done before then, the health is unknown.
+func (service *Service) HealthStatus(ctx context.Context, id string, started time.Time) string {
+	if !service.Healthcheck.Enabled() {
+		return ""
+	}
+
+	for failures := 0; failures < max(service.Healthcheck.retries(), 1); failures++ {
+		if failures > 0 {
+			select {
+			case <-ctx.Done():
+				return HealthUnknown
+			case <-time.After(healthRetryDelay):
+			}
+		}
+
+		if err := service.Healthcheck.Probe(ctx, id); err == nil {
+			return HealthHealthy
+		}
+		if ctx.Err() != nil {
+			return HealthUnknown
+		}
+		if time.Since(started) < service.Healthcheck.startPeriod() {
+			return HealthStarting
+		}
+	}
+
+	return HealthUnhealthy
+}
+
+// WaitHealthy probes the service's container every interval until it is healthy. It fails
+// once the container is unhealthy or the context is done.
+func (service *Service) WaitHealthy(ctx context.Context) error {
+	if !service.Healthcheck.Enabled() {
+		return fmt.Errorf("service %q has no healthcheck", service.ServiceName)
+	}
+
+	if service.Name == "" {
+		generated, err := service.GenerateName(ctx)
+		if err != nil {
+			return err
+		}
+		service.Name = generated
+	}
+
+	health := NewHealth(service.Healthcheck, time.Now())
+	for {
+		health.Record(service.Healthcheck.Probe(ctx, service.Name), time.Now())
+
+		switch health.Status {
+		case HealthHealthy:
+			return nil
+		case HealthUnhealthy:
+			return fmt.Errorf("service %q is unhealthy: %w", service.ServiceName, health.LastError)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(service.Healthcheck.interval()):
+		}
+	}
+}
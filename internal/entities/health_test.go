@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHealthcheckUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		wantCommand []string
+		wantEnabled bool
+		wantErr     bool
+	}{
+		{name: "string", yaml: `test: curl -f localhost`, wantCommand: []string{"/bin/sh", "-c", "curl -f localhost"}, wantEnabled: true},
+		{name: "CMD", yaml: `test: ["CMD", "curl", "-f", "localhost"]`, wantCommand: []string{"curl", "-f", "localhost"}, wantEnabled: true},
+		{name: "CMD-SHELL", yaml: `test: ["CMD-SHELL", "curl -f localhost"]`, wantCommand: []string{"/bin/sh", "-c", "curl -f localhost"}, wantEnabled: true},
+		{name: "NONE", yaml: `test: ["NONE"]`},
+		{name: "disabled", yaml: "test: [\"CMD\", \"true\"]\ndisable: true"},
+		{name: "no test", yaml: `interval: 10s`},
+		{name: "CMD without a command", yaml: `test: ["CMD"]`, wantErr: true},
+		{name: "unknown form", yaml: `test: ["RUN", "true"]`, wantErr: true},
+		{name: "invalid duration", yaml: "test: true\ninterval: soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Healthcheck
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %+v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if got.Enabled() != tt.wantEnabled {
+				t.Fatalf("Enabled() = %v, want %v", got.Enabled(), tt.wantEnabled)
+			}
+			if tt.wantEnabled && !slices.Equal(got.Command(), tt.wantCommand) {
+				t.Errorf("Command() = %q, want %q", got.Command(), tt.wantCommand)
+			}
+		})
+	}
+}
+
+func TestHealthRecord(t *testing.T) {
+	retries := 2
+	startPeriod := Duration(time.Minute)
+	check := &Healthcheck{Test: HealthcheckTest{"CMD", "true"}, Retries: &retries, StartPeriod: &startPeriod}
+	started := time.Now()
+	failed := errors.New("probe failed")
+
+	tests := []struct {
+		name    string
+		results []error
+		offsets []time.Duration // when each probe is taken, after the container started
+		want    string
+	}{
+		{
+			name:    "healthy once a probe passes",
+			results: []error{nil},
+			offsets: []time.Duration{time.Second},
+			want:    HealthHealthy,
+		},
+		{
+			name:    "failures during the start period do not count",
+			results: []error{failed, failed, failed},
+			offsets: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			want:    HealthStarting,
+		},
+		{
+			name:    "unhealthy after retries failures",
+			results: []error{failed, failed},
+			offsets: []time.Duration{2 * time.Minute, 3 * time.Minute},
+			want:    HealthUnhealthy,
+		},
+		{
+			name:    "a single failure is not enough",
+			results: []error{failed},
+			offsets: []time.Duration{2 * time.Minute},
+			want:    HealthStarting,
+		},
+		{
+			name:    "a pass resets the failing streak",
+			results: []error{nil, failed, nil, failed},
+			offsets: []time.Duration{time.Second, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute},
+			want:    HealthHealthy,
+		},
+		{
+			name:    "failures count once healthy, even in the start period",
+			results: []error{nil, failed, failed},
+			offsets: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			want:    HealthUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := NewHealth(check, started)
+			for i, result := range tt.results {
+				health.Record(result, started.Add(tt.offsets[i]))
+			}
+			if health.Status != tt.want {
+				t.Errorf("status = %q, want %q", health.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package entities
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Include is a compose project loaded into this one as a sub-project. Its files are read with
+// their own variables, from the env files or else the .env file in the project directory, and
+// their relative paths are relative to the project directory, which is the directory of the
+// first file unless it is given.
+type Include struct {
+	Path             []string `yaml:"path"`
+	EnvFile          []string `yaml:"env_file,omitempty"`
+	ProjectDirectory string   `yaml:"project_directory,omitempty"`
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for Include which handles both the path
+// of a compose file and an object with the paths, env files and project directory
+func (i *Include) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		i.Path = []string{value.Value}
+		return nil
+	}
+
+	if value.Kind == yaml.MappingNode {
+		var entry struct {
+			Path             yaml.Node `yaml:"path"`
+			EnvFile          yaml.Node `yaml:"env_file"`
+			ProjectDirectory string    `yaml:"project_directory"`
+		}
+		if err := value.Decode(&entry); err != nil {
+			return err
+		}
+
+		path, err := decodeStrings(&entry.Path, "path")
+		if err != nil {
+			return err
+		}
+		if len(path) == 0 {
+			return fmt.Errorf("line %d: invalid include: path is required", value.Line)
+		}
+
+		envFile, err := decodeStrings(&entry.EnvFile, "env_file")
+		if err != nil {
+			return err
+		}
+
+		*i = Include{Path: path, EnvFile: envFile, ProjectDirectory: entry.ProjectDirectory}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: include must be either a string or an object", value.Line)
+}
+
+// decodeStrings decodes a field which is either a single string or a list of them.
+func decodeStrings(value *yaml.Node, field string) ([]string, error) {
+	switch value.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		return []string{value.Value}, nil
+	case yaml.SequenceNode:
+		var values []string
+		if err := value.Decode(&values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("line %d: %s must be either a string or a list", value.Line, field)
+}
+
+// ResolveIncludes loads the compose files the compose file includes and adds their services,
+// volumes and networks to it. A resource defined in more than one place is an error. path is
+// the file the compose file was read from, and chain holds the files which included it.
+func (c *Compose) ResolveIncludes(path string, chain []string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	chain = append(chain, path)
+
+	for _, include := range c.Include {
+		included, err := include.Load(filepath.Dir(path), chain)
+		if err != nil {
+			return err
+		}
+
+		source := strings.Join(include.Path, ", ")
+		for key, service := range included.Services {
+			if _, ok := c.Services[key]; ok {
+				return fmt.Errorf("service %q from included %s is already defined", key, source)
+			}
+			if c.Services == nil {
+				c.Services = make(map[string]*Service)
+			}
+			c.Services[key] = service
+		}
+
+		for key, volume := range included.Volumes {
+			if _, ok := c.Volumes[key]; ok {
+				return fmt.Errorf("volume %q from included %s is already defined", key, source)
+			}
+			if c.Volumes == nil {
+				c.Volumes = make(map[string]*Volume)
+			}
+			c.Volumes[key] = volume
+		}
+
+		for key, network := range included.Networks {
+			if _, ok := c.Networks[key]; ok {
+				return fmt.Errorf("network %q from included %s is already defined", key, source)
+			}
+			if c.Networks == nil {
+				c.Networks = make(map[string]*Network)
+			}
+			c.Networks[key] = network
+		}
+	}
+
+	c.Include = nil
+	return nil
+}
+
+// Load reads the included compose files, relative to dir, as a project of their own. chain
+// holds the files which led to this include, so that a file which includes itself is caught.
+func (i Include) Load(dir string, chain []string) (Compose, error) {
+	paths := make([]string, len(i.Path))
+	for n, path := range i.Path {
+		resolved, err := resolvePath(path, dir)
+		if err != nil {
+			return Compose{}, err
+		}
+		if slices.Contains(chain, resolved) {
+			cycle := append(slices.Clone(chain[slices.Index(chain, resolved):]), resolved)
+			return Compose{}, fmt.Errorf("include cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+		paths[n] = resolved
+	}
+
+	projectDir := filepath.Dir(paths[0])
+	if i.ProjectDirectory != "" {
+		resolved, err := resolvePath(i.ProjectDirectory, dir)
+		if err != nil {
+			return Compose{}, err
+		}
+		projectDir = resolved
+	}
+
+	// the sub-project has its own variables
+	envFiles := []EnvFile{{Path: filepath.Join(projectDir, ".env")}}
+	if len(i.EnvFile) > 0 {
+		envFiles = envFiles[:0]
+		for _, path := range i.EnvFile {
+			resolved, err := resolvePath(path, dir)
+			if err != nil {
+				return Compose{}, err
+			}
+			envFiles = append(envFiles, EnvFile{Path: resolved, Required: true})
+		}
+	}
+	environment, err := loadEnvironment(envFiles...)
+	if err != nil {
+		return Compose{}, err
+	}
+
+	var config Compose
+	for n, path := range paths {
+		file, err := parseFile(path, environment.Lookup, chain)
+		if err != nil {
+			return Compose{}, err
+		}
+		if n == 0 {
+			config = file
+			continue
+		}
+		config.Merge(file)
+	}
+
+	if err := config.ResolvePaths(projectDir); err != nil {
+		return Compose{}, err
+	}
+
+	if err := config.ResolveEnvFiles(environment.Lookup); err != nil {
+		return Compose{}, err
+	}
+
+	return config, nil
+}
+
+// parseFile reads and parses the compose file at path, resolving the services it extends
+// and the files it includes. chain holds the files which included it.
+func parseFile(path string, lookup func(string) (string, bool), chain []string) (Compose, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return Compose{}, err
+	}
+
+	file, err := Parse(contents, lookup)
+	if err != nil {
+		return Compose{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := file.ResolveExtends(path, lookup); err != nil {
+		return Compose{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := file.ResolveIncludes(path, chain); err != nil {
+		return Compose{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestIncludeUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    Include
+		wantErr bool
+	}{
+		{
+			name: "path",
+			yaml: "db/compose.yaml",
+			want: Include{Path: []string{"db/compose.yaml"}},
+		},
+		{
+			name: "object",
+			yaml: "{path: [db/compose.yaml, db/override.yaml], env_file: db.env, project_directory: db}",
+			want: Include{Path: []string{"db/compose.yaml", "db/override.yaml"}, EnvFile: []string{"db.env"}, ProjectDirectory: "db"},
+		},
+		{
+			name:    "object without a path",
+			yaml:    "{env_file: db.env}",
+			wantErr: true,
+		},
+		{
+			name:    "list",
+			yaml:    "[db/compose.yaml]",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Include
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %+v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if strings.Join(got.Path, ",") != strings.Join(tt.want.Path, ",") ||
+				strings.Join(got.EnvFile, ",") != strings.Join(tt.want.EnvFile, ",") ||
+				got.ProjectDirectory != tt.want.ProjectDirectory {
+				t.Errorf("unmarshaling %s gave %+v, want %+v", tt.yaml, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveIncludes(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		check   func(t *testing.T, dir string, config Compose)
+		wantErr string
+	}{
+		{
+			name: "sub-project",
+			files: map[string]string{
+				"compose.yaml": `
+include:
+  - db/compose.yaml
+services:
+  web:
+    image: web
+    depends_on: [db]`,
+				"db/compose.yaml": `
+services:
+  db:
+    image: postgres:${PG_VERSION}
+    volumes: ["./data:/var/lib/postgresql/data", "pgdata:/backup"]
+volumes:
+  pgdata: {}`,
+				"db/.env": "PG_VERSION=16\n",
+			},
+			check: func(t *testing.T, dir string, config Compose) {
+				db := config.Services["db"]
+				if db == nil {
+					t.Fatalf("services = %v, want db included", sortedKeys(config.Services))
+				}
+				if db.Image != "postgres:16" {
+					t.Errorf("image = %q, want postgres:16 from the sub-project's .env", db.Image)
+				}
+				if want := filepath.Join(dir, "db", "data"); db.Volumes[0].Source != want {
+					t.Errorf("bind mount source = %q, want %q", db.Volumes[0].Source, want)
+				}
+				if config.Volumes["pgdata"] == nil {
+					t.Errorf("volume pgdata is missing")
+				}
+			},
+		},
+		{
+			name: "env file and project directory",
+			files: map[string]string{
+				"compose.yaml": `
+include:
+  - path: services/cache.yaml
+    env_file: cache.env
+    project_directory: cache`,
+				"services/cache.yaml": `
+services:
+  cache:
+    image: redis:${REDIS_VERSION}
+    volumes: ["./data:/data"]`,
+				"cache.env": "REDIS_VERSION=7\n",
+			},
+			check: func(t *testing.T, dir string, config Compose) {
+				cache := config.Services["cache"]
+				if cache.Image != "redis:7" {
+					t.Errorf("image = %q, want redis:7 from the include's env file", cache.Image)
+				}
+				if want := filepath.Join(dir, "cache", "data"); cache.Volumes[0].Source != want {
+					t.Errorf("bind mount source = %q, want %q relative to the project directory", cache.Volumes[0].Source, want)
+				}
+			},
+		},
+		{
+			name: "collision",
+			files: map[string]string{
+				"compose.yaml": `
+include: [other.yaml]
+services:
+  web:
+    image: web`,
+				"other.yaml": `
+services:
+  web:
+    image: other`,
+			},
+			wantErr: `service "web" from included other.yaml is already defined`,
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"compose.yaml": "include: [a.yaml]\n",
+				"a.yaml":       "include: [b.yaml]\n",
+				"b.yaml":       "include: [a.yaml]\n",
+			},
+			wantErr: "include cycle detected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			config, err := Load(filepath.Join(dir, "compose.yaml"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load returned an error: %v", err)
+			}
+			tt.check(t, dir, config)
+		})
+	}
+}
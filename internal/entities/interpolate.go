@@ -0,0 +1,230 @@
+package entities
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment holds the variables which are substituted into the compose file.
+type Environment map[string]string
+
+// Lookup returns the value of the variable and whether it is set.
+func (e Environment) Lookup(name string) (string, bool) {
+	value, ok := e[name]
+	return value, ok
+}
+
+// LoadEnvironment returns the variables for a project in dir: those in the project's .env
+// file, if it has one, overridden by the process environment.
+func LoadEnvironment(dir string) (Environment, error) {
+	return loadEnvironment(EnvFile{Path: filepath.Join(dir, ".env")})
+}
+
+// loadEnvironment returns the variables in the env files, later files overriding earlier
+// ones, overridden in turn by the process environment.
+func loadEnvironment(files ...EnvFile) (Environment, error) {
+	environment := make(Environment)
+
+	for _, file := range files {
+		variables, err := file.Read(LookupEnv)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range variables {
+			environment[key] = value
+		}
+	}
+
+	for _, variable := range os.Environ() {
+		key, value, _ := strings.Cut(variable, "=")
+		environment[key] = value
+	}
+
+	return environment, nil
+}
+
+// LookupEnv looks the variable up in the process environment.
+func LookupEnv(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+// Interpolate substitutes variables into every value in the document. Keys are left as they
+// are.
+func Interpolate(node *yaml.Node, lookup func(string) (string, bool)) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := Interpolate(child, lookup); err != nil {
+				return err
+			}
+		}
+
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := Interpolate(node.Content[i], lookup); err != nil {
+				return err
+			}
+		}
+
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "$") {
+			return nil
+		}
+		value, err := Substitute(node.Value, lookup)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		node.Value = value
+
+		// plain scalars are resolved again so that e.g. a substituted number decodes as one
+		if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+		}
+	}
+
+	return nil
+}
+
+// Substitute replaces the variables in the text. It supports $VAR and ${VAR}, the
+// ${VAR:-default}, ${VAR-default}, ${VAR:?error}, ${VAR?error}, ${VAR:+alternative} and
+// ${VAR+alternative} modifiers, which may be nested, and $$ for a literal $. A variable which
+// is not set is replaced with an empty string.
+func Substitute(text string, lookup func(string) (string, bool)) (string, error) {
+	var result strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' {
+			result.WriteByte(text[i])
+			continue
+		}
+
+		// a lone $ at the end is kept as it is
+		if i+1 == len(text) {
+			result.WriteByte('$')
+			continue
+		}
+
+		switch next := text[i+1]; {
+		case next == '$':
+			result.WriteByte('$')
+			i++
+
+		case next == '{':
+			end := closingBrace(text, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("invalid interpolation format for %q: unterminated ${", text)
+			}
+			value, err := expand(text[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(value)
+			i = end
+
+		case isNameStart(next):
+			end := i + 2
+			for end < len(text) && isNameChar(text[end]) {
+				end++
+			}
+			value, _ := lookup(text[i+1 : end])
+			result.WriteString(value)
+			i = end - 1
+
+		default:
+			result.WriteByte('$')
+		}
+	}
+
+	return result.String(), nil
+}
+
+// expand evaluates the contents of a ${...} expression.
+func expand(expression string, lookup func(string) (string, bool)) (string, error) {
+	end := 0
+	for end < len(expression) && isNameChar(expression[end]) {
+		end++
+	}
+	name, modifier := expression[:end], expression[end:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid interpolation format for ${%s}", expression)
+	}
+
+	value, set := lookup(name)
+	if modifier == "" {
+		return value, nil
+	}
+
+	// a leading colon makes an empty value count as unset
+	unset := !set
+	operator := modifier
+	if strings.HasPrefix(modifier, ":") {
+		unset = !set || value == ""
+		operator = modifier[1:]
+	}
+	if operator == "" {
+		return "", fmt.Errorf("invalid interpolation format for ${%s}", expression)
+	}
+	argument := operator[1:]
+
+	switch operator[0] {
+	case '-':
+		if unset {
+			return Substitute(argument, lookup)
+		}
+		return value, nil
+
+	case '?':
+		if unset {
+			message, err := Substitute(argument, lookup)
+			if err != nil {
+				return "", err
+			}
+			if message == "" {
+				message = "not set"
+			}
+			return "", fmt.Errorf("required variable %s is missing a value: %s", name, message)
+		}
+		return value, nil
+
+	case '+':
+		if unset {
+			return "", nil
+		}
+		return Substitute(argument, lookup)
+	}
+
+	return "", fmt.Errorf("invalid interpolation format for ${%s}", expression)
+}
+
+// closingBrace returns the index of the brace which closes the expression starting at start,
+// skipping over nested expressions, or -1 when there is none.
+func closingBrace(text string, start int) int {
+	depth := 1
+	for i := start; i < len(text); i++ {
+		switch {
+		case text[i] == '$' && i+1 < len(text) && text[i+1] == '$':
+			i++
+		case text[i] == '$' && i+1 < len(text) && text[i+1] == '{':
+			depth++
+			i++
+		case text[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
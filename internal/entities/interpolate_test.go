@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestSubstitute(t *testing.T) {
+	environment := Environment{
+		"NAME":  "web",
+		"EMPTY": "",
+		"PORT":  "8080",
+		"HOST":  "localhost",
+	}
+
+	tests := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{text: "plain", want: "plain"},
+		{text: "$NAME", want: "web"},
+		{text: "${NAME}", want: "web"},
+		{text: "$NAME-$PORT", want: "web-8080"},
+		{text: "${NAME}_1", want: "web_1"},
+		{text: "$NAME_1", want: ""},
+		{text: "$UNSET", want: ""},
+		{text: "$$NAME", want: "$NAME"},
+		{text: "$${NAME}", want: "${NAME}"},
+		{text: "cost: 5$", want: "cost: 5$"},
+		{text: "$1", want: "$1"},
+		{text: "${UNSET-default}", want: "default"},
+		{text: "${EMPTY-default}", want: ""},
+		{text: "${UNSET:-default}", want: "default"},
+		{text: "${EMPTY:-default}", want: "default"},
+		{text: "${NAME:-default}", want: "web"},
+		{text: "${UNSET+alternative}", want: ""},
+		{text: "${EMPTY+alternative}", want: "alternative"},
+		{text: "${EMPTY:+alternative}", want: ""},
+		{text: "${NAME:+alternative}", want: "alternative"},
+		{text: "${NAME?required}", want: "web"},
+		{text: "${EMPTY?required}", want: ""},
+		{text: "${UNSET?required}", wantErr: true},
+		{text: "${EMPTY:?required}", wantErr: true},
+		{text: "${UNSET:?}", wantErr: true},
+		{text: "${UNSET:-${HOST}:${PORT}}", want: "localhost:8080"},
+		{text: "${UNSET:-${ALSO_UNSET:-nested}}", want: "nested"},
+		{text: "${UNSET:-$$literal}", want: "$literal"},
+		{text: "${NAME", wantErr: true},
+		{text: "${}", wantErr: true},
+		{text: "${1NAME}", wantErr: true},
+		{text: "${NAME:}", wantErr: true},
+		{text: "${NAME*x}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := Substitute(tt.text, environment.Lookup)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Substitute(%q) = %q, want an error", tt.text, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Substitute(%q) returned an error: %v", tt.text, err)
+			}
+			if got != tt.want {
+				t.Errorf("Substitute(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInterpolates(t *testing.T) {
+	environment := Environment{
+		"TAG":     "1.25",
+		"RETRIES": "2",
+		"KEY":     "NOT_SUBSTITUTED",
+	}
+
+	config, err := Parse([]byte(`
+services:
+  web:
+    image: "nginx:${TAG}"
+    healthcheck:
+      test: ["CMD", "true"]
+      retries: ${RETRIES}
+    environment:
+      $KEY: value
+      QUOTED: '$${TAG}'
+`), environment.Lookup)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	web := config.Services["web"]
+	if web.Image != "nginx:1.25" {
+		t.Errorf("image = %q, want nginx:1.25", web.Image)
+	}
+	if web.Healthcheck == nil || web.Healthcheck.Retries == nil || *web.Healthcheck.Retries != 2 {
+		t.Errorf("healthcheck = %+v, want 2 retries, a substituted number decodes as one", web.Healthcheck)
+	}
+	if value, ok := web.EnvironmentVariables["$KEY"]; !ok || value != "value" {
+		t.Errorf("environment = %v, want the key $KEY left as it is", web.EnvironmentVariables)
+	}
+	if web.EnvironmentVariables["QUOTED"] != "${TAG}" {
+		t.Errorf("environment QUOTED = %q, want ${TAG}", web.EnvironmentVariables["QUOTED"])
+	}
+
+	if _, err := Parse([]byte("services:\n  web:\n    image: ${UNSET:?image is required}\n"), environment.Lookup); err == nil {
+		t.Errorf("Parse with a missing required variable succeeded, want an error")
+	}
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvironmentVariables are variables set in a container or passed to a build. A variable
+// without a value takes its value from the host environment, and is left out when the host
+// does not set it.
+type EnvironmentVariables map[string]string
+
+// UnmarshalYAML implements custom YAML unmarshaling for EnvironmentVariables which handles
+// both a map (KEY: value) and a list (- KEY=value)
+func (e *EnvironmentVariables) UnmarshalYAML(value *yaml.Node) error {
+	variables, err := decodeMapping(value, "environment", func(key string) (string, bool) {
+		return os.LookupEnv(key)
+	})
+	if err != nil {
+		return err
+	}
+	*e = variables
+	return nil
+}
+
+// Labels are metadata set on a container, image, volume or network. A label without a value
+// is set to an empty string.
+type Labels map[string]string
+
+// UnmarshalYAML implements custom YAML unmarshaling for Labels which handles both a map
+// (key: value) and a list (- key=value)
+func (l *Labels) UnmarshalYAML(value *yaml.Node) error {
+	labels, err := decodeMapping(value, "labels", func(string) (string, bool) {
+		return "", true
+	})
+	if err != nil {
+		return err
+	}
+	*l = labels
+	return nil
+}
+
+// decodeMapping decodes a map or a list of KEY=value entries. Numbers and booleans are kept
+// as they are written. Keys without a value are given the value bare returns for them, and
+// left out when it has none.
+func decodeMapping(value *yaml.Node, field string, bare func(string) (string, bool)) (map[string]string, error) {
+	mapping := make(map[string]string)
+
+	set := func(key string) {
+		if value, ok := bare(key); ok {
+			mapping[key] = value
+		}
+	}
+
+	switch value.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			key, item := value.Content[i], value.Content[i+1]
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: %s value for %q must be a string, number or boolean", item.Line, field, key.Value)
+			}
+			if item.ShortTag() == "!!null" {
+				set(key.Value)
+				continue
+			}
+			mapping[key.Value] = item.Value
+		}
+
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: %s entry must be a string", item.Line, field)
+			}
+			key, entry, hasValue := strings.Cut(item.Value, "=")
+			if key == "" {
+				return nil, fmt.Errorf("line %d: %s entry %q has no key", item.Line, field, item.Value)
+			}
+			if !hasValue {
+				set(key)
+				continue
+			}
+			mapping[key] = entry
+		}
+
+	case yaml.ScalarNode:
+		// an empty field
+		if value.ShortTag() != "!!null" {
+			return nil, fmt.Errorf("line %d: %s must be either a map or a list", value.Line, field)
+		}
+
+	default:
+		return nil, fmt.Errorf("line %d: %s must be either a map or a list", value.Line, field)
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"maps"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEnvironmentVariablesUnmarshalYAML(t *testing.T) {
+	t.Setenv("FROM_HOST", "host")
+
+	tests := []struct {
+		name    string
+		yaml    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "map",
+			yaml: "{A: one, B: 2, C: true, D: ''}",
+			want: map[string]string{"A": "one", "B": "2", "C": "true", "D": ""},
+		},
+		{
+			name: "list",
+			yaml: "[A=one, B=2, C=a=b, D=]",
+			want: map[string]string{"A": "one", "B": "2", "C": "a=b", "D": ""},
+		},
+		{
+			name: "keys without a value come from the host",
+			yaml: "[FROM_HOST, NOT_ON_HOST]",
+			want: map[string]string{"FROM_HOST": "host"},
+		},
+		{
+			name: "null values come from the host",
+			yaml: "{FROM_HOST: null, NOT_ON_HOST: ~}",
+			want: map[string]string{"FROM_HOST": "host"},
+		},
+		{
+			name: "empty",
+			yaml: "~",
+			want: map[string]string{},
+		},
+		{
+			name:    "nested value",
+			yaml:    "{A: {B: c}}",
+			wantErr: true,
+		},
+		{
+			name:    "entry without a key",
+			yaml:    "[=value]",
+			wantErr: true,
+		},
+		{
+			name:    "string",
+			yaml:    "A=one",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got EnvironmentVariables
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("unmarshaling %s gave %v, want %v", tt.yaml, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelsUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want map[string]string
+	}{
+		{
+			name: "map",
+			yaml: "{com.example.tier: web, com.example.replicas: 2}",
+			want: map[string]string{"com.example.tier": "web", "com.example.replicas": "2"},
+		},
+		{
+			name: "list",
+			yaml: "[com.example.tier=web, com.example.flag]",
+			want: map[string]string{"com.example.tier": "web", "com.example.flag": ""},
+		},
+		{
+			name: "null values are empty",
+			yaml: "{com.example.flag: null}",
+			want: map[string]string{"com.example.flag": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Labels
+			if err := yaml.Unmarshal([]byte(tt.yaml), &got); err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("unmarshaling %s gave %v, want %v", tt.yaml, got, tt.want)
+			}
+		})
+	}
+}
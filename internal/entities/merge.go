@@ -0,0 +1,236 @@
+package entities
+
+import (
+	"maps"
+)
+
+// Merge applies the compose file override on top of c, following the compose merge rules:
+// values replace values, maps are merged key by key, ports are merged by their definition
+// and volume mounts by their target.
+func (c *Compose) Merge(override Compose) {
+	if override.Name != "" {
+		c.Name = override.Name
+	}
+	if override.Version != "" {
+		c.Version = override.Version
+	}
+
+	if c.Services == nil && len(override.Services) > 0 {
+		c.Services = make(map[string]*Service)
+	}
+	for key, service := range override.Services {
+		if base, ok := c.Services[key]; ok {
+			base.Merge(service)
+			continue
+		}
+		c.Services[key] = service
+	}
+
+	if c.Volumes == nil && len(override.Volumes) > 0 {
+		c.Volumes = make(map[string]*Volume)
+	}
+	for key, volume := range override.Volumes {
+		if base, ok := c.Volumes[key]; ok {
+			base.Merge(volume)
+			continue
+		}
+		c.Volumes[key] = volume
+	}
+
+	if c.Networks == nil && len(override.Networks) > 0 {
+		c.Networks = make(map[string]*Network)
+	}
+	for key, network := range override.Networks {
+		if base, ok := c.Networks[key]; ok {
+			base.Merge(network)
+			continue
+		}
+		c.Networks[key] = network
+	}
+}
+
+// Merge applies the service override on top of the service.
+func (service *Service) Merge(override *Service) {
+	if override.Image != "" {
+		service.Image = override.Image
+	}
+	if override.Name != "" {
+		service.Name = override.Name
+	}
+	if override.Command != nil {
+		service.Command = override.Command
+	}
+	if override.Entrypoint != nil {
+		service.Entrypoint = override.Entrypoint
+	}
+	if override.WorkingDir != "" {
+		service.WorkingDir = override.WorkingDir
+	}
+	if override.User != "" {
+		service.User = override.User
+	}
+	if override.CPUs > 0 {
+		service.CPUs = override.CPUs
+	}
+	if override.MemLimit > 0 {
+		service.MemLimit = override.MemLimit
+	}
+	if override.Deploy != nil && override.Deploy.Resources.Limits != nil {
+		if service.Deploy == nil {
+			service.Deploy = &Deploy{}
+		}
+		if service.Deploy.Resources.Limits == nil {
+			service.Deploy.Resources.Limits = &ResourceLimits{}
+		}
+		service.Deploy.Resources.Limits.Merge(override.Deploy.Resources.Limits)
+	}
+
+	// ports which are defined the same way are only published once
+	for _, port := range override.Ports {
+		duplicate := false
+		for _, existing := range service.Ports {
+			if existing.String() == port.String() {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			service.Ports = append(service.Ports, port)
+		}
+	}
+
+	// a mount replaces the mount at the same target
+	for _, mount := range override.Volumes {
+		replaced := false
+		for i, existing := range service.Volumes {
+			if existing.Target == mount.Target {
+				service.Volumes[i] = mount
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			service.Volumes = append(service.Volumes, mount)
+		}
+	}
+
+	service.EnvironmentVariables = mergeMap(service.EnvironmentVariables, override.EnvironmentVariables)
+	service.Labels = mergeMap(service.Labels, override.Labels)
+	service.DependsOn = mergeMap(service.DependsOn, override.DependsOn)
+	service.Networks = mergeMap(service.Networks, override.Networks)
+	service.EnvFile = append(service.EnvFile, override.EnvFile...)
+	if len(override.Profiles) > 0 {
+		service.Profiles = override.Profiles
+	}
+
+	if override.Build != nil {
+		if service.Build == nil {
+			service.Build = &Build{}
+		}
+		service.Build.Merge(override.Build)
+	}
+
+	if override.Healthcheck != nil {
+		if service.Healthcheck == nil {
+			service.Healthcheck = &Healthcheck{}
+		}
+		service.Healthcheck.Merge(override.Healthcheck)
+	}
+}
+
+// Merge applies the build override on top of the build.
+func (b *Build) Merge(override *Build) {
+	if override.Context != "" {
+		b.Context = override.Context
+	}
+	if override.Dockerfile != "" {
+		b.Dockerfile = override.Dockerfile
+	}
+	if override.Target != "" {
+		b.Target = override.Target
+	}
+	if override.Network != "" {
+		b.Network = override.Network
+	}
+	b.Args = mergeMap(b.Args, override.Args)
+	b.Labels = mergeMap(b.Labels, override.Labels)
+	if override.NoCache != nil {
+		b.NoCache = override.NoCache
+	}
+	if override.Pull != nil {
+		b.Pull = override.Pull
+	}
+}
+
+// Merge applies the limits override on top of the limits.
+func (l *ResourceLimits) Merge(override *ResourceLimits) {
+	if override.CPUs > 0 {
+		l.CPUs = override.CPUs
+	}
+	if override.Memory > 0 {
+		l.Memory = override.Memory
+	}
+}
+
+// Merge applies the healthcheck override on top of the healthcheck.
+func (h *Healthcheck) Merge(override *Healthcheck) {
+	if len(override.Test) > 0 {
+		h.Test = override.Test
+	}
+	if override.Interval != nil {
+		h.Interval = override.Interval
+	}
+	if override.Timeout != nil {
+		h.Timeout = override.Timeout
+	}
+	if override.Retries != nil {
+		h.Retries = override.Retries
+	}
+	if override.StartPeriod != nil {
+		h.StartPeriod = override.StartPeriod
+	}
+	if override.Disable != nil {
+		h.Disable = override.Disable
+	}
+}
+
+// Merge applies the volume override on top of the volume.
+func (volume *Volume) Merge(override *Volume) {
+	if override.Name != "" {
+		volume.Name = override.Name
+	}
+	volume.DriverOpts = mergeMap(volume.DriverOpts, override.DriverOpts)
+	volume.Labels = mergeMap(volume.Labels, override.Labels)
+	if override.External != nil {
+		volume.External = override.External
+	}
+}
+
+// Merge applies the network override on top of the network.
+func (network *Network) Merge(override *Network) {
+	if override.Name != "" {
+		network.Name = override.Name
+	}
+	network.Labels = mergeMap(network.Labels, override.Labels)
+	if override.External != nil {
+		network.External = override.External
+	}
+}
+
+// isTrue reports whether the flag is set to true. Flags are pointers so that an override can
+// set them back to false.
+func isTrue(flag *bool) bool {
+	return flag != nil && *flag
+}
+
+// mergeMap copies the entries of override into base, creating base when it is nil.
+func mergeMap[M ~map[K]V, K comparable, V any](base, override M) M {
+	if len(override) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(M, len(override))
+	}
+	maps.Copy(base, override)
+	return base
+}
@@ -0,0 +1,254 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// parse parses the compose file, failing the test when it is invalid.
+func parse(t *testing.T, content string) Compose {
+	t.Helper()
+	config, err := Parse([]byte(content), Environment{}.Lookup)
+	if err != nil {
+		t.Fatalf("parsing the compose file: %v", err)
+	}
+	return config
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		override string
+		check    func(t *testing.T, config Compose)
+	}{
+		{
+			name: "values replace values",
+			base: `
+name: base
+services:
+  web:
+    image: nginx:1
+    container_name: web
+    command: npm start
+    working_dir: /app
+    mem_limit: 1g`,
+			override: `
+name: override
+services:
+  web:
+    image: nginx:2
+    command: ["npm", "run", "dev"]`,
+			check: func(t *testing.T, config Compose) {
+				web := config.Services["web"]
+				if config.Name != "override" {
+					t.Errorf("name = %q, want override", config.Name)
+				}
+				if web.Image != "nginx:2" {
+					t.Errorf("image = %q, want nginx:2", web.Image)
+				}
+				if web.Name != "web" {
+					t.Errorf("container_name = %q, want web", web.Name)
+				}
+				if !slices.Equal(web.Command, ShellCommand{"npm", "run", "dev"}) {
+					t.Errorf("command = %q, want [npm run dev]", web.Command)
+				}
+				if web.WorkingDir != "/app" {
+					t.Errorf("working_dir = %q, want /app", web.WorkingDir)
+				}
+				if web.MemLimit != 1<<30 {
+					t.Errorf("mem_limit = %d, want 1g", web.MemLimit)
+				}
+			},
+		},
+		{
+			name: "maps are merged by key",
+			base: `
+services:
+  web:
+    environment:
+      A: base
+      B: base
+    labels: [keep=base]`,
+			override: `
+services:
+  web:
+    environment:
+      B: override
+      C: override
+    labels: [added=override]`,
+			check: func(t *testing.T, config Compose) {
+				web := config.Services["web"]
+				want := EnvironmentVariables{"A": "base", "B": "override", "C": "override"}
+				if len(web.EnvironmentVariables) != len(want) {
+					t.Errorf("environment = %v, want %v", web.EnvironmentVariables, want)
+				}
+				for key, value := range want {
+					if web.EnvironmentVariables[key] != value {
+						t.Errorf("environment[%s] = %q, want %q", key, web.EnvironmentVariables[key], value)
+					}
+				}
+				if web.Labels["keep"] != "base" || web.Labels["added"] != "override" {
+					t.Errorf("labels = %v, want keep=base and added=override", web.Labels)
+				}
+			},
+		},
+		{
+			name: "ports are merged by definition",
+			base: `
+services:
+  web:
+    ports: ["8080:80", "443:443"]`,
+			override: `
+services:
+  web:
+    ports: ["443:443", "9090:90"]`,
+			check: func(t *testing.T, config Compose) {
+				var ports []string
+				for _, port := range config.Services["web"].Ports {
+					ports = append(ports, port.String())
+				}
+				want := []string{"8080:80", "443:443", "9090:90"}
+				if !slices.Equal(ports, want) {
+					t.Errorf("ports = %q, want %q", ports, want)
+				}
+			},
+		},
+		{
+			name: "mounts are merged by target",
+			base: `
+services:
+  web:
+    volumes: ["data:/data", "/host/logs:/logs"]`,
+			override: `
+services:
+  web:
+    volumes: ["other:/data:ro", "cache:/cache"]`,
+			check: func(t *testing.T, config Compose) {
+				var mounts []string
+				for _, mount := range config.Services["web"].Volumes {
+					mounts = append(mounts, mount.String())
+				}
+				want := []string{"other:/data:ro", "/host/logs:/logs", "cache:/cache"}
+				if !slices.Equal(mounts, want) {
+					t.Errorf("volumes = %q, want %q", mounts, want)
+				}
+			},
+		},
+		{
+			name: "new services, volumes and networks are added",
+			base: `
+services:
+  web:
+    image: nginx`,
+			override: `
+services:
+  worker:
+    image: worker
+volumes:
+  data: {}
+networks:
+  back: {}`,
+			check: func(t *testing.T, config Compose) {
+				if config.Services["web"] == nil || config.Services["worker"] == nil {
+					t.Errorf("services = %v, want web and worker", slices.Sorted(maps.Keys(config.Services)))
+				}
+				if config.Volumes["data"] == nil {
+					t.Errorf("volume data is missing")
+				}
+				if config.Networks["back"] == nil {
+					t.Errorf("network back is missing")
+				}
+			},
+		},
+		{
+			name: "an override sets flags back to false",
+			base: `
+services:
+  web:
+    build:
+      context: .
+      no_cache: true
+      pull: true
+    healthcheck:
+      test: ["CMD", "true"]
+      disable: true
+volumes:
+  data:
+    external: true
+networks:
+  back:
+    external: true`,
+			override: `
+services:
+  web:
+    build:
+      no_cache: false
+      pull: false
+    healthcheck:
+      disable: false
+volumes:
+  data:
+    external: false
+networks:
+  back:
+    external: false`,
+			check: func(t *testing.T, config Compose) {
+				web := config.Services["web"]
+				if isTrue(web.Build.NoCache) || isTrue(web.Build.Pull) {
+					t.Errorf("build no_cache = %v, pull = %v, want both false", isTrue(web.Build.NoCache), isTrue(web.Build.Pull))
+				}
+				if !web.Healthcheck.Enabled() {
+					t.Errorf("healthcheck is disabled, want it enabled")
+				}
+				if config.Volumes["data"].IsExternal() {
+					t.Errorf("volume data is external, want it managed by the project")
+				}
+				if config.Networks["back"].IsExternal() {
+					t.Errorf("network back is external, want it managed by the project")
+				}
+			},
+		},
+		{
+			name: "flags an override leaves out are kept",
+			base: `
+services:
+  web:
+    build:
+      context: .
+      no_cache: true
+volumes:
+  data:
+    external: true`,
+			override: `
+services:
+  web:
+    build:
+      target: dev
+volumes:
+  data:
+    labels: [a=b]`,
+			check: func(t *testing.T, config Compose) {
+				web := config.Services["web"]
+				if !isTrue(web.Build.NoCache) {
+					t.Errorf("build no_cache is false, want it kept true")
+				}
+				if web.Build.Context != "." || web.Build.Target != "dev" {
+					t.Errorf("build context = %q, target = %q, want . and dev", web.Build.Context, web.Build.Target)
+				}
+				if !config.Volumes["data"].IsExternal() {
+					t.Errorf("volume data is not external, want it kept external")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, tt.base)
+			config.Merge(parse(t, tt.override))
+			tt.check(t, config)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/container-compose/cli/internal/commands"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MountTypeBind   = "bind"
+	MountTypeVolume = "volume"
+	MountTypeTmpfs  = "tmpfs"
+)
+
+// VolumeMount is an entry of a service's volumes: a host path, a named volume or a tmpfs
+// mounted into the container. A volume without a source is anonymous.
+type VolumeMount struct {
+	Type     string `yaml:"type"`
+	Source   string `yaml:"source,omitempty"`
+	Target   string `yaml:"target"`
+	ReadOnly bool   `yaml:"read_only,omitempty"`
+
+	long bool // written in the long syntax, so it is passed to --mount
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for VolumeMount which handles both the
+// short syntax ("./data:/data:ro") and the long syntax (type, source, target, read_only)
+func (v *VolumeMount) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		mount, err := ParseVolumeMount(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*v = mount
+		return nil
+	}
+
+	if value.Kind == yaml.MappingNode {
+		type volumeMountAlias VolumeMount
+		aux := (*volumeMountAlias)(v)
+		if err := value.Decode(aux); err != nil {
+			return err
+		}
+		v.long = true
+
+		switch v.Type {
+		case MountTypeBind, MountTypeVolume, MountTypeTmpfs:
+		default:
+			return fmt.Errorf("line %d: invalid volume type %q, must be one of: bind, volume, tmpfs", value.Line, v.Type)
+		}
+		if v.Target == "" {
+			return fmt.Errorf("line %d: invalid volume: target is required", value.Line)
+		}
+		if v.Type == MountTypeBind && v.Source == "" {
+			return fmt.Errorf("line %d: invalid volume: a bind mount needs a source", value.Line)
+		}
+		if v.Type == MountTypeTmpfs && v.Source != "" {
+			return fmt.Errorf("line %d: invalid volume: a tmpfs mount cannot have a source", value.Line)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: volume must be either a string or an object", value.Line)
+}
+
+// MarshalYAML writes the mount in the syntax it was written in
+func (v VolumeMount) MarshalYAML() (interface{}, error) {
+	if v.long {
+		type volumeMountAlias VolumeMount
+		return volumeMountAlias(v), nil
+	}
+	return v.String(), nil
+}
+
+// ParseVolumeMount parses a mount in the short syntax, [SOURCE:]TARGET[:MODE]. A source which
+// is a path is bind mounted, any other source names a volume.
+func ParseVolumeMount(spec string) (VolumeMount, error) {
+	parts := strings.Split(spec, ":")
+
+	mount := VolumeMount{Type: MountTypeVolume}
+	switch len(parts) {
+	case 1:
+		mount.Target = parts[0]
+	case 2, 3:
+		mount.Source, mount.Target = parts[0], parts[1]
+		if len(parts) == 3 {
+			switch parts[2] {
+			case "ro":
+				mount.ReadOnly = true
+			case "rw":
+			default:
+				return VolumeMount{}, fmt.Errorf("invalid volume %q: mode must be ro or rw", spec)
+			}
+		}
+	default:
+		return VolumeMount{}, fmt.Errorf("invalid volume %q: too many colons", spec)
+	}
+
+	if mount.Target == "" || !strings.HasPrefix(mount.Target, "/") {
+		return VolumeMount{}, fmt.Errorf("invalid volume %q: target must be an absolute path", spec)
+	}
+
+	if isPath(mount.Source) {
+		mount.Type = MountTypeBind
+	}
+
+	return mount, nil
+}
+
+// isPath reports whether the source of a mount is a host path rather than a volume name.
+func isPath(source string) bool {
+	return strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~")
+}
+
+// String formats the mount in the short syntax.
+func (v VolumeMount) String() string {
+	spec := v.Target
+	if v.Source != "" {
+		spec = v.Source + ":" + spec
+	}
+	if v.ReadOnly {
+		spec += ":ro"
+	}
+	return spec
+}
+
+// Resolve makes the source of a bind mount absolute. Relative paths are relative to dir and
+// ~ is the user's home directory.
+func (v *VolumeMount) Resolve(dir string) error {
+	if v.Type != MountTypeBind {
+		return nil
+	}
+
+	source, err := resolvePath(v.Source, dir)
+	if err != nil {
+		return err
+	}
+	v.Source = source
+	return nil
+}
+
+// resolvePath makes the path absolute. Relative paths are relative to dir and ~ is the
+// user's home directory.
+func resolvePath(path, dir string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, path[1:]), nil
+	}
+
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+
+	return filepath.Join(dir, path), nil
+}
+
+// Argument returns the container run flag and value which create the mount. Mounts which
+// cannot be written as --volume are passed to --mount.
+func (v VolumeMount) Argument() (string, string) {
+	if !v.long && v.Type != MountTypeTmpfs && v.Source != "" {
+		return "--volume", v.String()
+	}
+
+	options := []string{"type=" + v.Type}
+	if v.Source != "" {
+		options = append(options, "source="+v.Source)
+	}
+	options = append(options, "target="+v.Target)
+	if v.ReadOnly {
+		options = append(options, "readonly")
+	}
+	return "--mount", strings.Join(options, ",")
+}
+
+// FromMount converts a mount reported by the container engine.
+func FromMount(mount commands.Mount) VolumeMount {
+	volume := VolumeMount{
+		Type:     MountTypeBind,
+		Source:   mount.Source,
+		Target:   mount.Destination,
+		ReadOnly: mount.ReadOnly(),
+	}
+
+	switch {
+	case mount.Type["tmpfs"] != nil:
+		volume.Type = MountTypeTmpfs
+		volume.Source = ""
+	case mount.Type["volume"] != nil:
+		volume.Type = MountTypeVolume
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(mount.Type["volume"], &named); err == nil && named.Name != "" {
+			volume.Source = named.Name
+		}
+	}
+
+	return volume
+}
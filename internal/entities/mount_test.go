@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseVolumeMount(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    VolumeMount
+		wantErr bool
+	}{
+		{spec: "/data", want: VolumeMount{Type: MountTypeVolume, Target: "/data"}},
+		{spec: "data:/data", want: VolumeMount{Type: MountTypeVolume, Source: "data", Target: "/data"}},
+		{spec: "data:/data:ro", want: VolumeMount{Type: MountTypeVolume, Source: "data", Target: "/data", ReadOnly: true}},
+		{spec: "data:/data:rw", want: VolumeMount{Type: MountTypeVolume, Source: "data", Target: "/data"}},
+		{spec: "./src:/app", want: VolumeMount{Type: MountTypeBind, Source: "./src", Target: "/app"}},
+		{spec: "../shared:/shared:ro", want: VolumeMount{Type: MountTypeBind, Source: "../shared", Target: "/shared", ReadOnly: true}},
+		{spec: "/var/log:/logs", want: VolumeMount{Type: MountTypeBind, Source: "/var/log", Target: "/logs"}},
+		{spec: "~/cache:/cache", want: VolumeMount{Type: MountTypeBind, Source: "~/cache", Target: "/cache"}},
+		{spec: "data", wantErr: true},
+		{spec: "data:relative", wantErr: true},
+		{spec: "data:/data:rx", wantErr: true},
+		{spec: "data:/data:ro:extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseVolumeMount(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseVolumeMount(%q) = %+v, want an error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVolumeMount(%q) returned an error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseVolumeMount(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVolumeMountArgument(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		wantFlag  string
+		wantValue string
+		wantErr   bool
+	}{
+		{name: "named volume", yaml: `"data:/data:ro"`, wantFlag: "--volume", wantValue: "data:/data:ro"},
+		{name: "bind mount", yaml: `"/host:/container"`, wantFlag: "--volume", wantValue: "/host:/container"},
+		{name: "anonymous volume", yaml: `"/data"`, wantFlag: "--mount", wantValue: "type=volume,target=/data"},
+		{name: "long syntax", yaml: `{type: bind, source: /host, target: /container, read_only: true}`, wantFlag: "--mount", wantValue: "type=bind,source=/host,target=/container,readonly"},
+		{name: "tmpfs", yaml: `{type: tmpfs, target: /tmp}`, wantFlag: "--mount", wantValue: "type=tmpfs,target=/tmp"},
+		{name: "unknown type", yaml: `{type: npipe, target: /pipe}`, wantErr: true},
+		{name: "long syntax without a target", yaml: `{type: volume, source: data}`, wantErr: true},
+		{name: "bind mount without a source", yaml: `{type: bind, target: /container}`, wantErr: true},
+		{name: "tmpfs with a source", yaml: `{type: tmpfs, source: data, target: /tmp}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mount VolumeMount
+			err := yaml.Unmarshal([]byte(tt.yaml), &mount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %+v, want an error", tt.yaml, mount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			flag, value := mount.Argument()
+			if flag != tt.wantFlag || value != tt.wantValue {
+				t.Errorf("Argument() = %s %s, want %s %s", flag, value, tt.wantFlag, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestVolumeMountResolve(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{spec: "./src:/app", want: "/project/src"},
+		{spec: "../shared:/shared", want: "/shared"},
+		{spec: "/abs:/abs", want: "/abs"},
+		{spec: "~/cache:/cache", want: home + "/cache"},
+		{spec: "data:/data", want: "data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			mount, err := ParseVolumeMount(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseVolumeMount(%q) returned an error: %v", tt.spec, err)
+			}
+			if err := mount.Resolve("/project"); err != nil {
+				t.Fatalf("Resolve returned an error: %v", err)
+			}
+			if mount.Source != tt.want {
+				t.Errorf("source = %q, want %q", mount.Source, tt.want)
+			}
+		})
+	}
+}
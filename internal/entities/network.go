@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/container-compose/cli/internal/commands"
+	"gopkg.in/yaml.v3"
+)
+
+// LabelNetwork is set on every network to the key it is declared under
+const LabelNetwork = "com.container-compose.network"
+
+// Network is a network declared in the top level networks section.
+type Network struct {
+	Key     string `yaml:"-"`
+	Project string `yaml:"-"`
+
+	Name     string `yaml:"name,omitempty"`
+	Labels   Labels `yaml:"labels,omitempty"`
+	External *bool  `yaml:"external,omitempty"`
+}
+
+// IsExternal reports whether the network is managed outside the project, in which case it is
+// never created or deleted.
+func (network *Network) IsExternal() bool {
+	return isTrue(network.External)
+}
+
+// ServiceNetwork is how a service attaches to one of the networks. The container engine
+// reaches containers by their name only, so aliases are kept but not applied.
+type ServiceNetwork struct {
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// ServiceNetworks are the networks a service attaches to, keyed by network.
+type ServiceNetworks map[string]*ServiceNetwork
+
+// UnmarshalYAML implements custom YAML unmarshaling for ServiceNetworks which handles both
+// a list of network names and a map of networks to their attachment options
+func (n *ServiceNetworks) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var names []string
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+		*n = make(ServiceNetworks, len(names))
+		for _, name := range names {
+			(*n)[name] = nil
+		}
+		return nil
+	}
+
+	if value.Kind == yaml.MappingNode {
+		networks := map[string]*ServiceNetwork{}
+		if err := value.Decode(&networks); err != nil {
+			return err
+		}
+		*n = networks
+		return nil
+	}
+
+	return fmt.Errorf("line %d: networks must be either a list or a map", value.Line)
+}
+
+// MarshalYAML writes the networks as a list of names unless one of them has options
+func (n ServiceNetworks) MarshalYAML() (interface{}, error) {
+	names := make([]string, 0, len(n))
+	for name, attachment := range n {
+		if attachment != nil {
+			return map[string]*ServiceNetwork(n), nil
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ResolveNetworks gives every declared network its name and points the services' networks
+// at those names. Networks are named after the project unless they are external or named
+// explicitly. A service which attaches to an undeclared network is an error.
+func (c *Compose) ResolveNetworks() error {
+	for key, network := range c.Networks {
+		if network.Name != "" {
+			continue
+		}
+		if network.IsExternal() {
+			network.Name = key
+			continue
+		}
+		network.Name = fmt.Sprintf("%s_%s", c.Name, key)
+	}
+
+	for _, service := range c.Services {
+		if len(service.Networks) == 0 {
+			continue
+		}
+
+		resolved := make(ServiceNetworks, len(service.Networks))
+		for key, attachment := range service.Networks {
+			network, ok := c.Networks[key]
+			if !ok {
+				return fmt.Errorf("service %q uses undeclared network %q", service.ServiceName, key)
+			}
+			resolved[network.Name] = attachment
+		}
+		service.Networks = resolved
+	}
+
+	return nil
+}
+
+// UsedNetworks returns the declared networks which the services attach to, sorted by key.
+// Networks which only services disabled by their profiles attach to are left out. The
+// services' networks have to be resolved first.
+func (c Compose) UsedNetworks() []*Network {
+	used := make(map[string]bool)
+	for _, service := range c.Services {
+		for name := range service.Networks {
+			used[name] = true
+		}
+	}
+
+	keys := make([]string, 0, len(c.Networks))
+	for key := range c.Networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var networks []*Network
+	for _, key := range keys {
+		if network := c.Networks[key]; used[network.Name] {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+// Exists checks if the network exists.
+func (network *Network) Exists(ctx context.Context) (bool, error) {
+	cmd, err := commands.NetworkInspect(network.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if err := cmd.Exec(ctx); err != nil {
+		return false, nil // If inspect fails, assume the network doesn't exist
+	}
+
+	return true, nil
+}
+
+// CreateCommand creates a command to create the network, labelled with the project.
+func (network *Network) CreateCommand(ctx context.Context) (*commands.NetworkCreateCommand, error) {
+	if network.IsExternal() {
+		return nil, fmt.Errorf("network %q is external and has to be created outside of the project", network.Name)
+	}
+
+	cmd, err := commands.NetworkCreate(network.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]string)
+	for k, v := range network.Labels {
+		labels[k] = v
+	}
+	if network.Project != "" {
+		labels[LabelProject] = network.Project
+	}
+	labels[LabelNetwork] = network.Key
+	cmd.SetLabels(labels)
+
+	return cmd, nil
+}
+
+// DeleteCommand creates a command to delete the network.
+func (network *Network) DeleteCommand(ctx context.Context) (*commands.NetworkDeleteCommand, error) {
+	if network.IsExternal() {
+		return nil, fmt.Errorf("network %q is external and is not managed by the project", network.Name)
+	}
+
+	return commands.NetworkDelete(network.Name)
+}
+
+// Up makes sure the network exists, creating it when it is missing. It reports whether the
+// network had to be created.
+func (network *Network) Up(ctx context.Context) (bool, error) {
+	exists, err := network.Exists(ctx)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	if network.IsExternal() {
+		return false, fmt.Errorf("external network %q does not exist", network.Name)
+	}
+
+	cmd, err := network.CreateCommand(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, cmd.Exec(ctx)
+}
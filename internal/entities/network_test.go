@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestServiceNetworksUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		want        []string
+		wantAliases []string // the aliases on front
+		wantErr     bool
+	}{
+		{name: "list", yaml: "[front, back]", want: []string{"back", "front"}},
+		{name: "map", yaml: "{front: {}, back: null}", want: []string{"back", "front"}},
+		{name: "aliases", yaml: "{front: {aliases: [www, api]}}", want: []string{"front"}, wantAliases: []string{"www", "api"}},
+		{name: "string", yaml: "front", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ServiceNetworks
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("unmarshaling %s gave %v, want %v", tt.yaml, got, tt.want)
+			}
+			for _, name := range tt.want {
+				if _, ok := got[name]; !ok {
+					t.Errorf("unmarshaling %s gave %v, want %s", tt.yaml, got, name)
+				}
+			}
+			var aliases []string
+			if front := got["front"]; front != nil {
+				aliases = front.Aliases
+			}
+			if !slices.Equal(aliases, tt.wantAliases) {
+				t.Errorf("unmarshaling %s gave aliases %q, want %q", tt.yaml, aliases, tt.wantAliases)
+			}
+		})
+	}
+}
+
+func TestResolveNetworks(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantNames    map[string]string // network key to name
+		wantNetworks []string          // the networks web attaches to
+		wantErr      string
+	}{
+		{
+			name: "named after the project",
+			content: `
+name: shop
+services:
+  web:
+    networks: [front, back]
+networks:
+  front: {}
+  back: {}`,
+			wantNames:    map[string]string{"front": "shop_front", "back": "shop_back"},
+			wantNetworks: []string{"shop_back", "shop_front"},
+		},
+		{
+			name: "named explicitly and external",
+			content: `
+name: shop
+services:
+  web:
+    networks:
+      front:
+        aliases: [www]
+      shared: {}
+networks:
+  front:
+    name: public
+  shared:
+    external: true`,
+			wantNames:    map[string]string{"front": "public", "shared": "shared"},
+			wantNetworks: []string{"public", "shared"},
+		},
+		{
+			name: "undeclared network",
+			content: `
+name: shop
+services:
+  web:
+    networks: [front]`,
+			wantErr: `service "web" uses undeclared network "front"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, tt.content)
+
+			err := config.ResolveNetworks()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveNetworks returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveNetworks returned an error: %v", err)
+			}
+
+			for key, want := range tt.wantNames {
+				if got := config.Networks[key].Name; got != want {
+					t.Errorf("network %s name = %q, want %q", key, got, want)
+				}
+			}
+			got := slices.Sorted(maps.Keys(config.Services["web"].Networks))
+			if !slices.Equal(got, tt.wantNetworks) {
+				t.Errorf("web networks = %q, want %q", got, tt.wantNetworks)
+			}
+		})
+	}
+}
+
+func TestUsedNetworks(t *testing.T) {
+	const content = `
+name: shop
+services:
+  web:
+    networks: [front]
+  debug:
+    profiles: [debug]
+    networks: [front, back]
+networks:
+  front: {}
+  back: {}
+  unused: {}`
+
+	tests := []struct {
+		name     string
+		profiles []string
+		want     []string
+	}{
+		{name: "without the profile", want: []string{"shop_front"}},
+		{name: "with the profile", profiles: []string{"debug"}, want: []string{"shop_back", "shop_front"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, content)
+			if err := config.ResolveNetworks(); err != nil {
+				t.Fatalf("ResolveNetworks returned an error: %v", err)
+			}
+			config.ApplyProfiles(tt.profiles)
+
+			var got []string
+			for _, network := range config.UsedNetworks() {
+				got = append(got, network.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("used networks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
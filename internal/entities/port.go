@@ -0,0 +1,253 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/container-compose/cli/internal/commands"
+	"gopkg.in/yaml.v3"
+)
+
+// Port is a port of the container, optionally published on the host. A port may cover a
+// range, in which case the host range is the same length as the container range.
+type Port struct {
+	HostIP         string
+	HostStart      int // zero when the port is not published
+	HostEnd        int
+	ContainerStart int
+	ContainerEnd   int
+	Protocol       string
+}
+
+// UnmarshalYAML implements custom YAML unmarshaling for Port which handles both the short
+// syntax ("127.0.0.1:8080:80/tcp") and the long syntax (target, published, host_ip, protocol)
+func (p *Port) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		port, err := ParsePort(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*p = port
+		return nil
+	}
+
+	if value.Kind == yaml.MappingNode {
+		var long struct {
+			Target    string `yaml:"target"`
+			Published string `yaml:"published"`
+			HostIP    string `yaml:"host_ip"`
+			Protocol  string `yaml:"protocol"`
+		}
+		if err := value.Decode(&long); err != nil {
+			return err
+		}
+		if long.Target == "" {
+			return fmt.Errorf("line %d: invalid port: target is required", value.Line)
+		}
+
+		spec := long.Target
+		if long.Published != "" {
+			spec = long.Published + ":" + spec
+			if long.HostIP != "" {
+				spec = formatHostIP(long.HostIP) + ":" + spec
+			}
+		}
+		if long.Protocol != "" {
+			spec += "/" + long.Protocol
+		}
+
+		port, err := ParsePort(spec)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*p = port
+		return nil
+	}
+
+	return fmt.Errorf("line %d: port must be either a string or an object", value.Line)
+}
+
+// MarshalYAML writes the port in the short syntax
+func (p Port) MarshalYAML() (interface{}, error) {
+	return p.String(), nil
+}
+
+// ParsePort parses a port in the short syntax, [[HOST_IP:]HOST:]CONTAINER[/PROTOCOL], where
+// HOST and CONTAINER are either a single port or a range such as 8000-8010. The container
+// engine needs a host port for every container port, so a random host port (127.0.0.1::80)
+// and a host range for a single container port (8000-8010:80) are not supported.
+func ParsePort(spec string) (Port, error) {
+	invalid := func(format string, args ...interface{}) (Port, error) {
+		return Port{}, fmt.Errorf("invalid port %q: %s", spec, fmt.Sprintf(format, args...))
+	}
+
+	port := Port{Protocol: "tcp"}
+	rest := spec
+
+	// split off the protocol
+	if i := strings.LastIndex(rest, "/"); i >= 0 {
+		port.Protocol = strings.ToLower(rest[i+1:])
+		rest = rest[:i]
+		if port.Protocol != "tcp" && port.Protocol != "udp" {
+			return invalid("protocol must be tcp or udp")
+		}
+	}
+
+	// split off an IPv6 host address, which contains colons of its own
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]:")
+		if end < 0 {
+			return invalid("unterminated IPv6 address")
+		}
+		port.HostIP = rest[1:end]
+		rest = rest[end+2:]
+		if !strings.Contains(rest, ":") {
+			return invalid("a host address needs a host port")
+		}
+		if strings.HasPrefix(rest, ":") {
+			return invalid("a random host port is not supported by the container engine, give the host port")
+		}
+	}
+
+	parts := strings.Split(rest, ":")
+	var host, container string
+	switch len(parts) {
+	case 1:
+		container = parts[0]
+	case 2:
+		host, container = parts[0], parts[1]
+	case 3:
+		if port.HostIP != "" {
+			return invalid("too many colons")
+		}
+		port.HostIP, host, container = parts[0], parts[1], parts[2]
+		if port.HostIP == "" {
+			return invalid("host address is empty")
+		}
+		if host == "" {
+			return invalid("a random host port is not supported by the container engine, give the host port")
+		}
+	default:
+		return invalid("too many colons")
+	}
+
+	var err error
+	port.ContainerStart, port.ContainerEnd, err = parsePortRange(container)
+	if err != nil {
+		return invalid("container port %s", err)
+	}
+
+	if host == "" {
+		if port.HostIP != "" {
+			return invalid("a host address needs a host port")
+		}
+		return port, nil
+	}
+
+	port.HostStart, port.HostEnd, err = parsePortRange(host)
+	if err != nil {
+		return invalid("host port %s", err)
+	}
+
+	if port.ContainerStart == port.ContainerEnd && port.HostStart != port.HostEnd {
+		return invalid("publishing a container port on any port of a host range is not supported by the container engine, give a single host port")
+	}
+	if port.HostEnd-port.HostStart != port.ContainerEnd-port.ContainerStart {
+		return invalid("host and container port ranges are different sizes")
+	}
+
+	return port, nil
+}
+
+// parsePortRange parses a single port or a range of ports.
+func parsePortRange(value string) (int, int, error) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+
+	start, err := parsePortNumber(startValue)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+
+	end, err := parsePortNumber(endValue)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("range %q ends before it starts", value)
+	}
+
+	return start, end, nil
+}
+
+func parsePortNumber(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	if n < 1 || n > 65535 {
+		return 0, fmt.Errorf("%d is not between 1 and 65535", n)
+	}
+	return n, nil
+}
+
+// Published reports whether the port is published on the host.
+func (p Port) Published() bool {
+	return p.HostStart != 0
+}
+
+// String formats the port in the short syntax, leaving out the protocol when it is tcp.
+func (p Port) String() string {
+	spec := formatPortRange(p.ContainerStart, p.ContainerEnd)
+	if p.Published() {
+		spec = formatPortRange(p.HostStart, p.HostEnd) + ":" + spec
+		if p.HostIP != "" {
+			spec = formatHostIP(p.HostIP) + ":" + spec
+		}
+	}
+	if p.Protocol != "" && p.Protocol != "tcp" {
+		spec += "/" + p.Protocol
+	}
+	return spec
+}
+
+func formatPortRange(start, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// formatHostIP brackets IPv6 addresses so they can be told apart from the ports.
+func formatHostIP(ip string) string {
+	if strings.Contains(ip, ":") && !strings.HasPrefix(ip, "[") {
+		return "[" + ip + "]"
+	}
+	return ip
+}
+
+// FromPublishedPort converts a port reported by the container engine.
+func FromPublishedPort(published commands.PublishedPort) Port {
+	count := max(published.Count, 1)
+	protocol := published.Protocol
+	if protocol == "" {
+		protocol = "tcp"
+	}
+
+	hostIP := published.HostAddress
+	if hostIP == "0.0.0.0" {
+		hostIP = ""
+	}
+
+	return Port{
+		HostIP:         hostIP,
+		HostStart:      published.HostPort,
+		HostEnd:        published.HostPort + count - 1,
+		ContainerStart: published.ContainerPort,
+		ContainerEnd:   published.ContainerPort + count - 1,
+		Protocol:       protocol,
+	}
+}
@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Port
+		wantErr string
+	}{
+		{spec: "80", want: Port{ContainerStart: 80, ContainerEnd: 80, Protocol: "tcp"}},
+		{spec: "8080:80", want: Port{HostStart: 8080, HostEnd: 8080, ContainerStart: 80, ContainerEnd: 80, Protocol: "tcp"}},
+		{spec: "53:53/udp", want: Port{HostStart: 53, HostEnd: 53, ContainerStart: 53, ContainerEnd: 53, Protocol: "udp"}},
+		{spec: "53:53/UDP", want: Port{HostStart: 53, HostEnd: 53, ContainerStart: 53, ContainerEnd: 53, Protocol: "udp"}},
+		{spec: "127.0.0.1:8080:80", want: Port{HostIP: "127.0.0.1", HostStart: 8080, HostEnd: 8080, ContainerStart: 80, ContainerEnd: 80, Protocol: "tcp"}},
+		{spec: "[::1]:8080:80", want: Port{HostIP: "::1", HostStart: 8080, HostEnd: 8080, ContainerStart: 80, ContainerEnd: 80, Protocol: "tcp"}},
+		{spec: "8000-8010:9000-9010", want: Port{HostStart: 8000, HostEnd: 8010, ContainerStart: 9000, ContainerEnd: 9010, Protocol: "tcp"}},
+		{spec: "3000-3005", want: Port{ContainerStart: 3000, ContainerEnd: 3005, Protocol: "tcp"}},
+		{spec: "80/sctp", wantErr: "invalid port"},
+		{spec: "http", wantErr: "invalid port"},
+		{spec: "0", wantErr: "invalid port"},
+		{spec: "65536", wantErr: "invalid port"},
+		{spec: "8080:", wantErr: "invalid port"},
+		{spec: ":8080:80", wantErr: "invalid port"},
+		{spec: "1:2:3:4", wantErr: "invalid port"},
+		{spec: "[::1:8080:80", wantErr: "invalid port"},
+		{spec: "[::1]:80", wantErr: "invalid port"},
+		{spec: "8010-8000:80", wantErr: "invalid port"},
+		{spec: "127.0.0.1::80", wantErr: "a random host port is not supported"},
+		{spec: "[::1]::80", wantErr: "a random host port is not supported"},
+		{spec: "8000-8001:80", wantErr: "any port of a host range is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParsePort(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParsePort(%q) = %+v, %v, want an error containing %q", tt.spec, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePort(%q) returned an error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePort(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortString(t *testing.T) {
+	tests := []string{
+		"80",
+		"8080:80",
+		"53:53/udp",
+		"127.0.0.1:8080:80",
+		"[::1]:8080:80",
+		"8000-8010:9000-9010",
+	}
+
+	for _, spec := range tests {
+		port, err := ParsePort(spec)
+		if err != nil {
+			t.Fatalf("ParsePort(%q) returned an error: %v", spec, err)
+		}
+		if got := port.String(); got != spec {
+			t.Errorf("ParsePort(%q).String() = %q, want it unchanged", spec, got)
+		}
+	}
+}
+
+func TestPortUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    string
+		wantErr bool
+	}{
+		{name: "short syntax", yaml: `"8080:80"`, want: "8080:80"},
+		{name: "number", yaml: `80`, want: "80"},
+		{name: "long syntax", yaml: `{target: 80, published: 8080, host_ip: 127.0.0.1, protocol: udp}`, want: "127.0.0.1:8080:80/udp"},
+		{name: "long syntax with a range", yaml: `{target: 80-81, published: "8080-8081"}`, want: "8080-8081:80-81"},
+		{name: "long syntax with IPv6", yaml: `{target: 80, published: 8080, host_ip: "::1"}`, want: "[::1]:8080:80"},
+		{name: "long syntax without a target", yaml: `{published: 8080}`, wantErr: true},
+		{name: "list", yaml: `[80]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Port
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %v, want an error", tt.yaml, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.yaml, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("unmarshaling %s gave %v, want %v", tt.yaml, got, tt.want)
+			}
+		})
+	}
+}
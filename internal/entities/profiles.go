@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// ActiveProfiles returns the profiles to enable: those given, or when there are none those
+// listed in the COMPOSE_PROFILES environment variable, separated by commas.
+func ActiveProfiles(profiles []string) []string {
+	if len(profiles) > 0 {
+		return profiles
+	}
+
+	var active []string
+	for _, profile := range strings.Split(os.Getenv("COMPOSE_PROFILES"), ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			active = append(active, profile)
+		}
+	}
+	return active
+}
+
+// ApplyProfiles disables the services which are not in any of the enabled profiles, moving
+// them out of Services. Services without profiles are always enabled, as is every service in
+// the profiles of the named services. The profile * enables every service.
+func (c *Compose) ApplyProfiles(profiles []string, names ...string) {
+	enabled := slices.Clone(profiles)
+	for _, name := range names {
+		if service, ok := c.Services[name]; ok {
+			enabled = append(enabled, service.Profiles...)
+		}
+	}
+
+	if slices.Contains(enabled, "*") {
+		return
+	}
+
+	for key, service := range c.Services {
+		if len(service.Profiles) == 0 || slices.ContainsFunc(service.Profiles, func(profile string) bool {
+			return slices.Contains(enabled, profile)
+		}) {
+			continue
+		}
+
+		if c.Disabled == nil {
+			c.Disabled = make(map[string]*Service)
+		}
+		c.Disabled[key] = service
+		delete(c.Services, key)
+	}
+}
+
+// IsDefined reports whether the service is defined in the compose file, whether or not its
+// profiles are enabled.
+func (c Compose) IsDefined(name string) bool {
+	_, enabled := c.Services[name]
+	_, disabled := c.Disabled[name]
+	return enabled || disabled
+}
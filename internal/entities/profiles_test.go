@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestActiveProfiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		profiles    []string
+		want        []string
+	}{
+		{name: "none"},
+		{name: "from the environment", environment: "debug, tools,,", want: []string{"debug", "tools"}},
+		{name: "from the flag", profiles: []string{"debug"}, want: []string{"debug"}},
+		{name: "the flag wins over the environment", environment: "tools", profiles: []string{"debug"}, want: []string{"debug"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COMPOSE_PROFILES", tt.environment)
+
+			if got := ActiveProfiles(tt.profiles); !slices.Equal(got, tt.want) {
+				t.Errorf("ActiveProfiles(%q) = %q, want %q", tt.profiles, got, tt.want)
+			}
+		})
+	}
+}
+
+const profiledServices = `
+services:
+  web:
+    depends_on: [db]
+  db: {}
+  debug:
+    profiles: [debug]
+  admin:
+    profiles: [tools, admin]
+    depends_on: [db]
+  report:
+    profiles: [tools]
+    depends_on: [debug]`
+
+func TestApplyProfiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		profiles     []string
+		services     []string
+		wantEnabled  []string
+		wantDisabled []string
+	}{
+		{
+			name:         "services without profiles stay enabled",
+			wantEnabled:  []string{"db", "web"},
+			wantDisabled: []string{"admin", "debug", "report"},
+		},
+		{
+			name:         "any matching profile",
+			profiles:     []string{"admin"},
+			wantEnabled:  []string{"admin", "db", "web"},
+			wantDisabled: []string{"debug", "report"},
+		},
+		{
+			name:         "a named service enables its own profiles",
+			services:     []string{"admin"},
+			wantEnabled:  []string{"admin", "db", "report", "web"},
+			wantDisabled: []string{"debug"},
+		},
+		{
+			name:        "every profile",
+			profiles:    []string{"*"},
+			wantEnabled: []string{"admin", "db", "debug", "report", "web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, profiledServices)
+			config.ApplyProfiles(tt.profiles, tt.services...)
+
+			if got := sortedKeys(config.Services); !slices.Equal(got, tt.wantEnabled) {
+				t.Errorf("enabled services = %q, want %q", got, tt.wantEnabled)
+			}
+			if got := sortedKeys(config.Disabled); !slices.Equal(got, tt.wantDisabled) {
+				t.Errorf("disabled services = %q, want %q", got, tt.wantDisabled)
+			}
+			for _, name := range []string{"web", "db", "debug", "admin", "report"} {
+				if !config.IsDefined(name) {
+					t.Errorf("IsDefined(%q) = false, want true", name)
+				}
+			}
+			if config.IsDefined("missing") {
+				t.Error(`IsDefined("missing") = true, want false`)
+			}
+		})
+	}
+}
+
+func TestDisabledDependency(t *testing.T) {
+	config := parse(t, profiledServices)
+	config.ApplyProfiles([]string{"tools"})
+
+	const want = `service "report" depends on service "debug", whose profiles are not enabled`
+	if _, err := config.Order(); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Order returned %v, want an error containing %q", err, want)
+	}
+	if _, err := config.Select("report"); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Select returned %v, want an error containing %q", err, want)
+	}
+}
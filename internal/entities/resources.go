@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/container-compose/cli/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// CPUs is a number of CPUs, which may be fractional in the compose file.
+type CPUs float64
+
+// UnmarshalYAML implements custom YAML unmarshaling for CPUs which handles both a number and
+// a string holding one
+func (c *CPUs) UnmarshalYAML(value *yaml.Node) error {
+	cpus, err := strconv.ParseFloat(value.Value, 64)
+	if err != nil || cpus <= 0 {
+		return fmt.Errorf("line %d: invalid cpus %q, must be a positive number", value.Line, value.Value)
+	}
+	*c = CPUs(cpus)
+	return nil
+}
+
+// Whole returns the number of whole CPUs to allocate. The container engine only allocates
+// whole CPUs, so a fraction is rounded up.
+func (c CPUs) Whole() int {
+	return int(math.Ceil(float64(c)))
+}
+
+// MemorySize is an amount of memory in bytes.
+type MemorySize int64
+
+// UnmarshalYAML implements custom YAML unmarshaling for MemorySize which handles both a
+// number of bytes and a size with a unit, e.g. 512m or 2g
+func (m *MemorySize) UnmarshalYAML(value *yaml.Node) error {
+	size, err := ParseMemorySize(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*m = size
+	return nil
+}
+
+// memoryUnits are the units of a memory size, each a power of 1024.
+var memoryUnits = map[string]int64{
+	"":  1,
+	"b": 1,
+	"k": 1 << 10, "kb": 1 << 10,
+	"m": 1 << 20, "mb": 1 << 20,
+	"g": 1 << 30, "gb": 1 << 30,
+	"t": 1 << 40, "tb": 1 << 40,
+}
+
+// ParseMemorySize parses a memory size: a number of bytes, optionally followed by a unit of
+// b, k, m, g or t, each of which may also be followed by b, in either case.
+func ParseMemorySize(value string) (MemorySize, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+	end := len(trimmed)
+	for end > 0 && (trimmed[end-1] < '0' || trimmed[end-1] > '9') {
+		end--
+	}
+
+	number, unit := trimmed[:end], trimmed[end:]
+	multiplier, ok := memoryUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid memory size %q, unknown unit %q", value, unit)
+	}
+
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid memory size %q, must be a positive number of bytes with an optional unit such as 512m or 2g", value)
+	}
+
+	return MemorySize(math.Ceil(size * float64(multiplier))), nil
+}
+
+// Argument formats the size the way the container engine takes it, a whole number of
+// megabytes, rounding up.
+func (m MemorySize) Argument() string {
+	megabytes := (int64(m) + 1<<20 - 1) >> 20
+	return fmt.Sprintf("%dMB", megabytes)
+}
+
+// Deploy holds the deployment configuration of a service. Only the resource limits are
+// applied, the rest concerns orchestrators.
+type Deploy struct {
+	Resources Resources `yaml:"resources,omitempty"`
+}
+
+// Resources holds the resource limits of a service.
+type Resources struct {
+	Limits *ResourceLimits `yaml:"limits,omitempty"`
+}
+
+// ResourceLimits are the most resources a service's containers may use.
+type ResourceLimits struct {
+	CPUs   CPUs       `yaml:"cpus,omitempty"`
+	Memory MemorySize `yaml:"memory,omitempty"`
+}
+
+// ResourceLimits returns the CPUs and memory the service's containers are limited to, zero
+// when they are not limited. The limits under deploy take precedence over cpus and
+// mem_limit.
+func (service *Service) ResourceLimits() (CPUs, MemorySize) {
+	cpus, memory := service.CPUs, service.MemLimit
+	if service.Deploy != nil && service.Deploy.Resources.Limits != nil {
+		limits := service.Deploy.Resources.Limits
+		if limits.CPUs > 0 {
+			cpus = limits.CPUs
+		}
+		if limits.Memory > 0 {
+			memory = limits.Memory
+		}
+	}
+	return cpus, memory
+}
+
+// resourceArguments returns the CPUs and memory to pass to the container engine, warning
+// when a fraction of a CPU has to be rounded up.
+func (service *Service) resourceArguments(ctx context.Context) (int, string) {
+	cpus, memory := service.ResourceLimits()
+
+	wholeCPUs := cpus.Whole()
+	if float64(wholeCPUs) != float64(cpus) {
+		logger.FromContext(ctx).WarnContext(ctx, "the container engine only allocates whole cpus, rounding up", "service", service.ServiceName, "cpus", float64(cpus), "allocated", wholeCPUs)
+	}
+
+	memoryArgument := ""
+	if memory > 0 {
+		memoryArgument = memory.Argument()
+	}
+
+	return wholeCPUs, memoryArgument
+}
@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseMemorySize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    MemorySize
+		wantErr bool
+	}{
+		{value: "1024", want: 1024},
+		{value: "512b", want: 512},
+		{value: "512m", want: 512 << 20},
+		{value: "512M", want: 512 << 20},
+		{value: "64kb", want: 64 << 10},
+		{value: "1.5g", want: 1536 << 20},
+		{value: "2GB", want: 2 << 30},
+		{value: "1t", want: 1 << 40},
+		{value: " 256mb ", want: 256 << 20},
+		{value: "-1g", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "12x", wantErr: true},
+		{value: "g", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseMemorySize(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMemorySize(%q) = %d, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMemorySize(%q) returned an error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMemorySize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemorySizeArgument(t *testing.T) {
+	tests := []struct {
+		size MemorySize
+		want string
+	}{
+		{size: 512 << 20, want: "512MB"},
+		{size: 2 << 30, want: "2048MB"},
+		{size: 1536 << 20, want: "1536MB"},
+		{size: 1, want: "1MB"},
+		{size: 1<<20 + 1, want: "2MB"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.size.Argument(); got != tt.want {
+			t.Errorf("MemorySize(%d).Argument() = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestCPUs(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    CPUs
+		whole   int
+		wantErr bool
+	}{
+		{value: "2", want: 2, whole: 2},
+		{value: "0.5", want: 0.5, whole: 1},
+		{value: "1.25", want: 1.25, whole: 2},
+		{value: `"3"`, want: 3, whole: 3},
+		{value: "0", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var got CPUs
+			err := yaml.Unmarshal([]byte(tt.value), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshaling %s gave %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshaling %s returned an error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("unmarshaling %s gave %v, want %v", tt.value, got, tt.want)
+			}
+			if whole := got.Whole(); whole != tt.whole {
+				t.Errorf("CPUs(%v).Whole() = %d, want %d", got, whole, tt.whole)
+			}
+		})
+	}
+}
+
+func TestResourceLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		service    Service
+		wantCPUs   CPUs
+		wantMemory MemorySize
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:       "service level",
+			service:    Service{CPUs: 2, MemLimit: 1 << 30},
+			wantCPUs:   2,
+			wantMemory: 1 << 30,
+		},
+		{
+			name: "deploy takes precedence",
+			service: Service{CPUs: 2, MemLimit: 1 << 30, Deploy: &Deploy{Resources: Resources{
+				Limits: &ResourceLimits{CPUs: 4, Memory: 2 << 30},
+			}}},
+			wantCPUs:   4,
+			wantMemory: 2 << 30,
+		},
+		{
+			name: "deploy fills in what it sets",
+			service: Service{CPUs: 2, MemLimit: 1 << 30, Deploy: &Deploy{Resources: Resources{
+				Limits: &ResourceLimits{Memory: 2 << 30},
+			}}},
+			wantCPUs:   2,
+			wantMemory: 2 << 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpus, memory := tt.service.ResourceLimits()
+			if cpus != tt.wantCPUs || memory != tt.wantMemory {
+				t.Errorf("ResourceLimits() = %v, %d, want %v, %d", cpus, memory, tt.wantCPUs, tt.wantMemory)
+			}
+		})
+	}
+}
@@ -6,32 +6,50 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/container-compose/cli/internal/commands"
+	"github.com/container-compose/cli/internal/logger"
 	"github.com/goombaio/namegenerator"
 	"gopkg.in/yaml.v3"
 )
 
 type Service struct {
-	Image                string            `yaml:"image"`
-	Name                 string            `yaml:"container_name"`
-	Ports                []string          `yaml:"ports"`
-	EnvironmentVariables map[string]string `yaml:"environment"`
-	Labels               map[string]string `yaml:"labels"`
-	Volumes              []string          `yaml:"volumes"`
-	Build                *Build            `yaml:"build,omitempty"`
+	ServiceName          string               `yaml:"-"`
+	Project              string               `yaml:"-"`
+	Image                string               `yaml:"image"`
+	Name                 string               `yaml:"container_name"`
+	Ports                []Port               `yaml:"ports"`
+	EnvironmentVariables EnvironmentVariables `yaml:"environment"`
+	Labels               Labels               `yaml:"labels"`
+	Volumes              []VolumeMount        `yaml:"volumes"`
+	Build                *Build               `yaml:"build,omitempty"`
+	DependsOn            Dependencies         `yaml:"depends_on,omitempty"`
+	Networks             ServiceNetworks      `yaml:"networks,omitempty"`
+	Healthcheck          *Healthcheck         `yaml:"healthcheck,omitempty"`
+	EnvFile              EnvFiles             `yaml:"env_file,omitempty"`
+	Profiles             []string             `yaml:"profiles,omitempty"`
+	Extends              *Extends             `yaml:"extends,omitempty"`
+	Command              ShellCommand         `yaml:"command,omitempty"`
+	Entrypoint           ShellCommand         `yaml:"entrypoint,omitempty"`
+	WorkingDir           string               `yaml:"working_dir,omitempty"`
+	User                 string               `yaml:"user,omitempty"`
+	CPUs                 CPUs                 `yaml:"cpus,omitempty"`
+	MemLimit             MemorySize           `yaml:"mem_limit,omitempty"`
+	Deploy               *Deploy              `yaml:"deploy,omitempty"`
 }
 
 type Build struct {
-	Context    string            `yaml:"context,omitempty"`
-	Dockerfile string            `yaml:"dockerfile,omitempty"`
-	Args       map[string]string `yaml:"args,omitempty"`
-	Labels     map[string]string `yaml:"labels,omitempty"`
-	Target     string            `yaml:"target,omitempty"`
-	Network    string            `yaml:"network,omitempty"`
-	NoCache    bool              `yaml:"no_cache,omitempty"`
-	Pull       bool              `yaml:"pull,omitempty"`
+	Context    string               `yaml:"context,omitempty"`
+	Dockerfile string               `yaml:"dockerfile,omitempty"`
+	Args       EnvironmentVariables `yaml:"args,omitempty"`
+	Labels     Labels               `yaml:"labels,omitempty"`
+	Target     string               `yaml:"target,omitempty"`
+	Network    string               `yaml:"network,omitempty"`
+	NoCache    *bool                `yaml:"no_cache,omitempty"`
+	Pull       *bool                `yaml:"pull,omitempty"`
 }
 
 // UnmarshalYAML implements custom YAML unmarshaling for Build which
@@ -61,17 +79,12 @@ func (b *Build) UnmarshalYAML(value *yaml.Node) error {
 // will be different.
 func (service *Service) GenerateName(ctx context.Context) (string, error) {
 
-	// marshall the service to a string
-	data, err := yaml.Marshal(service)
+	// create a seed based on the hash of the service configuration
+	hexstr, err := service.Hash()
 	if err != nil {
 		return "", err
 	}
-
-	// create a seed based on the hash of the service configuration
 	seed := big.NewInt(0)
-	hash := md5.New()
-	hash.Write([]byte(data))
-	hexstr := hex.EncodeToString(hash.Sum(nil))
 	seed.SetString(hexstr, 16)
 
 	// create a name generator based on the seed
@@ -79,6 +92,25 @@ func (service *Service) GenerateName(ctx context.Context) (string, error) {
 	return nameGenerator.Generate(), nil
 }
 
+// Hash returns a hash of the service configuration, which changes whenever the
+// configuration does. The project and service names are part of it, so that services which
+// are configured the same way in different projects, or under different names, never share a
+// container.
+func (service *Service) Hash() (string, error) {
+
+	// marshall the service to a string
+	data, err := yaml.Marshal(service)
+	if err != nil {
+		return "", err
+	}
+
+	// the names are not marshalled, so they are added on their own
+	hash := md5.New()
+	fmt.Fprintf(hash, "%s\n%s\n", service.Project, service.ServiceName)
+	hash.Write([]byte(data))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // Exists checks if the service exists.
 func (service *Service) Exists(ctx context.Context) (bool, error) {
 	if service.Name == "" {
@@ -129,8 +161,74 @@ func (service *Service) IsRunning(ctx context.Context) (bool, error) {
 	return results[0].Status == "running", nil
 }
 
+// Up makes sure the service is running. An existing container is started again, otherwise a
+// new container is run. It reports whether the service had to be started.
+func (service *Service) Up(ctx context.Context) (bool, error) {
+
+	// check if the service is already running
+	isRunning, err := service.IsRunning(ctx)
+	if err != nil {
+		return false, err
+	}
+	if isRunning {
+		return false, nil
+	}
+
+	// if we already have the service, but it's not running, start it
+	exists, err := service.Exists(ctx)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		cmd, err := service.StartCommand(ctx)
+		if err != nil {
+			return false, err
+		}
+		return true, cmd.Exec(ctx)
+	}
+
+	// run the service
+	cmd, err := service.RunCommand(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, cmd.Exec(ctx)
+}
+
+// Complete runs the service in the foreground until it exits, starting its existing container
+// again when there is one. A non-zero exit code is returned as an ExitError.
+func (service *Service) Complete(ctx context.Context) error {
+
+	// a running container cannot be attached to, so there is no way to get its exit code
+	isRunning, err := service.IsRunning(ctx)
+	if err != nil {
+		return err
+	}
+	if isRunning {
+		return fmt.Errorf("service %q is already running and cannot be waited on to complete", service.ServiceName)
+	}
+
+	exists, err := service.Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		cmd, err := service.StartCommand(ctx)
+		if err != nil {
+			return err
+		}
+		return cmd.SetAttach(true).Exec(ctx)
+	}
+
+	cmd, err := service.RunCommand(ctx)
+	if err != nil {
+		return err
+	}
+	return cmd.SetAttach(true).Exec(ctx)
+}
+
 // RunCommand creates a command to run the service.
-// If the service has build configuration, it will build the image first.
+// If the service has build configuration and its image is missing, it will build the image first.
 func (service *Service) RunCommand(ctx context.Context) (*commands.RunCommand, error) {
 
 	if service.Name == "" {
@@ -141,33 +239,68 @@ func (service *Service) RunCommand(ctx context.Context) (*commands.RunCommand, e
 		service.Name = generated
 	}
 
-	// Check if we need to build the image first
-	if service.Build != nil {
-		buildCmd, err := service.BuildCommand(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create build command: %w", err)
-		}
-
-		// Execute the build command
-		if err := buildCmd.Exec(ctx); err != nil {
-			return nil, fmt.Errorf("failed to build image: %w", err)
-		}
+	if err := service.EnsureImage(ctx, false); err != nil {
+		return nil, err
+	}
 
-		// If no image was specified, use the built image tag
-		if service.Image == "" {
-			if service.Build.Context != "" {
-				// Use the service name as the image tag
-				service.Image = service.Name
-			}
-		}
+	cmd, err := commands.Run(service.Name, service.EnvironmentVariables, service.ContainerLabels())
+	if err != nil {
+		return nil, err
 	}
 
-	cmd, err := commands.Run(service.Name, service.EnvironmentVariables, service.Labels)
+	// a service which is only built runs the image it was tagged with
+	image, err := service.ImageReference(ctx)
 	if err != nil {
 		return nil, err
 	}
+	cmd.Image(image)
+
+	// the container engine takes the entrypoint's executable alone, so the rest of the
+	// entrypoint goes ahead of the command
+	arguments := []string(service.Command)
+	if len(service.Entrypoint) > 0 {
+		cmd.SetEntrypoint(service.Entrypoint[0])
+		arguments = append(slices.Clone(service.Entrypoint[1:]), service.Command...)
+	}
+	cmd.SetArguments(arguments)
+	cmd.SetWorkingDir(service.WorkingDir).SetUser(service.User)
+
+	// limit the resources the container may use
+	cpus, memory := service.resourceArguments(ctx)
+	cmd.SetCPUs(cpus).SetMemory(memory)
+
+	// publish the ports, ports without a host port are reachable on the container's address
+	var publish []string
+	for _, port := range service.Ports {
+		if port.Published() {
+			publish = append(publish, port.String())
+		}
+	}
+	cmd.SetPublish(publish)
 
-	cmd.Image(service.Image)
+	// mount the volumes
+	var volumes, mounts []string
+	for _, volume := range service.Volumes {
+		flag, value := volume.Argument()
+		if flag == "--mount" {
+			mounts = append(mounts, value)
+		} else {
+			volumes = append(volumes, value)
+		}
+	}
+	cmd.SetVolumes(volumes).SetMounts(mounts)
+
+	// attach the networks, the container engine resolves containers by name only so
+	// aliases cannot be applied
+	networks := make([]string, 0, len(service.Networks))
+	for name, attachment := range service.Networks {
+		networks = append(networks, name)
+		if attachment != nil && len(attachment.Aliases) > 0 {
+			logger.FromContext(ctx).WarnContext(ctx, "network aliases are not supported by the container engine", "service", service.ServiceName, "network", name, "aliases", attachment.Aliases)
+		}
+	}
+	sort.Strings(networks)
+	cmd.SetNetworks(networks)
 
 	return cmd, nil
 }
@@ -270,33 +403,118 @@ func (service *Service) BuildCommand(ctx context.Context) (*commands.BuildComman
 	}
 
 	// Set no-cache
-	if service.Build.NoCache {
+	if isTrue(service.Build.NoCache) {
 		cmd.SetNoCache(true)
 	}
 
 	// Set pull
-	if service.Build.Pull {
+	if isTrue(service.Build.Pull) {
 		cmd.SetPull(true)
 	}
 
+	// Give the build the resources the service's containers are limited to, the builder's
+	// defaults apply otherwise
+	cpus, memory := service.resourceArguments(ctx)
+	if cpus > 0 {
+		cmd.SetCPUs(cpus)
+	}
+	if memory != "" {
+		cmd.SetMemory(memory)
+	}
+
 	// Set tag - use the service image name if specified, otherwise use service name
-	tag := service.Image
-	if tag == "" {
-		if service.Name == "" {
-			generated, err := service.GenerateName(ctx)
-			if err != nil {
-				return nil, err
-			}
-			tag = generated
-		} else {
-			tag = service.Name
-		}
+	tag, err := service.ImageReference(ctx)
+	if err != nil {
+		return nil, err
 	}
 	cmd.SetTag(tag)
 
 	return cmd, nil
 }
 
+// EnsureImage builds the image of a service with a build configuration when the image does
+// not exist yet, or every time when rebuild is set.
+func (service *Service) EnsureImage(ctx context.Context, rebuild bool) error {
+	if service.Build == nil {
+		return nil
+	}
+
+	if !rebuild {
+		exists, err := service.ImageExists(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	buildCmd, err := service.BuildCommand(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create build command: %w", err)
+	}
+
+	// Execute the build command
+	if err := buildCmd.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to build image: %w", err)
+	}
+
+	return nil
+}
+
+// ImageExists checks if the service's image exists locally.
+func (service *Service) ImageExists(ctx context.Context) (bool, error) {
+	reference, err := service.ImageReference(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	cmd, err := commands.ImageInspect(reference)
+	if err != nil {
+		return false, err
+	}
+
+	if err := cmd.Exec(ctx); err != nil {
+		return false, nil // If inspect fails, assume the image doesn't exist
+	}
+
+	return true, nil
+}
+
+// ImageReference returns the image the service runs. Services which are only built are
+// tagged with the service's container name.
+func (service *Service) ImageReference(ctx context.Context) (string, error) {
+	if service.Image != "" {
+		return service.Image, nil
+	}
+
+	if service.Name == "" {
+		generated, err := service.GenerateName(ctx)
+		if err != nil {
+			return "", err
+		}
+		return generated, nil
+	}
+
+	return service.Name, nil
+}
+
+// ContainerLabels returns the labels to set on the service's container. These are the
+// service's own labels plus the labels identifying the project and service.
+func (service *Service) ContainerLabels() map[string]string {
+	labels := make(map[string]string)
+	for k, v := range service.Labels {
+		labels[k] = v
+	}
+	if service.Project != "" {
+		labels[LabelProject] = service.Project
+	}
+	if service.ServiceName != "" {
+		labels[LabelService] = service.ServiceName
+	}
+	return labels
+}
+
 // NeedsBuild checks if the service needs to be built (has build config but no image)
 func (service *Service) NeedsBuild() bool {
 	return service.Build != nil && service.Image == ""
@@ -336,12 +554,33 @@ func FromInspectResult(result commands.InspectResult) *Service {
 		}
 	}
 
-	// Extract ports from labels or other configuration if available
-	// This is a simplified implementation as the inspect format doesn't directly show port mappings
-	var ports []string
+	// Convert the published ports
+	var ports []Port
+	for _, published := range result.Configuration.PublishedPorts {
+		ports = append(ports, FromPublishedPort(published))
+	}
+
+	// Convert the mounts
+	var volumes []VolumeMount
+	for _, mount := range result.Configuration.Mounts {
+		volumes = append(volumes, FromMount(mount))
+	}
+
+	// Convert the process, the entrypoint only holds the executable as the rest of it is
+	// part of the arguments
+	var entrypoint ShellCommand
+	if result.Configuration.InitProcess.Executable != "" {
+		entrypoint = ShellCommand{result.Configuration.InitProcess.Executable}
+	}
 
-	// Extract volumes from mounts if available
-	var volumes []string
+	// Convert the networks
+	var networks ServiceNetworks
+	for _, network := range result.Configuration.Networks {
+		if networks == nil {
+			networks = make(ServiceNetworks)
+		}
+		networks[network.Network] = nil
+	}
 
 	return &Service{
 		Image:                result.Configuration.Image.Reference,
@@ -350,5 +589,10 @@ func FromInspectResult(result commands.InspectResult) *Service {
 		EnvironmentVariables: envVars,
 		Labels:               result.Configuration.Labels,
 		Volumes:              volumes,
+		Networks:             networks,
+		Command:              result.Configuration.InitProcess.Arguments,
+		Entrypoint:           entrypoint,
+		WorkingDir:           result.Configuration.InitProcess.WorkingDirectory,
+		User:                 result.Configuration.InitProcess.User.String(),
 	}
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateName(t *testing.T) {
+	service := func(project, name string) *Service {
+		return &Service{Project: project, ServiceName: name, Image: "nginx"}
+	}
+
+	tests := []struct {
+		name     string
+		a, b     *Service
+		wantSame bool
+	}{
+		{name: "same service", a: service("shop", "web"), b: service("shop", "web"), wantSame: true},
+		{name: "another project", a: service("shop", "web"), b: service("blog", "web")},
+		{name: "another service", a: service("shop", "web"), b: service("shop", "admin")},
+		{name: "another configuration", a: service("shop", "web"), b: &Service{Project: "shop", ServiceName: "web", Image: "httpd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := tt.a.GenerateName(context.Background())
+			if err != nil {
+				t.Fatalf("GenerateName returned an error: %v", err)
+			}
+			b, err := tt.b.GenerateName(context.Background())
+			if err != nil {
+				t.Fatalf("GenerateName returned an error: %v", err)
+			}
+			if tt.wantSame && a != b {
+				t.Errorf("names = %q and %q, want them equal", a, b)
+			}
+			if !tt.wantSame && a == b {
+				t.Errorf("names are both %q, want them to differ", a)
+			}
+		})
+	}
+}
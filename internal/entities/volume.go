@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/container-compose/cli/internal/commands"
+)
+
+// LabelVolume is set on every volume to the key it is declared under
+const LabelVolume = "com.container-compose.volume"
+
+// Volume is a named volume declared in the top level volumes section.
+type Volume struct {
+	Key     string `yaml:"-"`
+	Project string `yaml:"-"`
+
+	Name       string            `yaml:"name,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	Labels     Labels            `yaml:"labels,omitempty"`
+	External   *bool             `yaml:"external,omitempty"`
+}
+
+// IsExternal reports whether the volume is managed outside the project, in which case it is
+// never created or deleted.
+func (volume *Volume) IsExternal() bool {
+	return isTrue(volume.External)
+}
+
+// ResolveVolumes gives every declared volume its name and points the services' mounts at
+// those names. Volumes are named after the project unless they are external or named
+// explicitly. A service which mounts an undeclared volume is an error.
+func (c *Compose) ResolveVolumes() error {
+	for key, volume := range c.Volumes {
+		if volume.Name != "" {
+			continue
+		}
+		if volume.IsExternal() {
+			volume.Name = key
+			continue
+		}
+		volume.Name = fmt.Sprintf("%s_%s", c.Name, key)
+	}
+
+	for _, service := range c.Services {
+		for i, mount := range service.Volumes {
+			if mount.Type != MountTypeVolume || mount.Source == "" {
+				continue
+			}
+			volume, ok := c.Volumes[mount.Source]
+			if !ok {
+				return fmt.Errorf("service %q uses undeclared volume %q", service.ServiceName, mount.Source)
+			}
+			service.Volumes[i].Source = volume.Name
+		}
+	}
+
+	return nil
+}
+
+// UsedVolumes returns the declared volumes which the services mount, sorted by key. Volumes
+// which only services disabled by their profiles mount are left out. The mounts have to be
+// resolved first.
+func (c Compose) UsedVolumes() []*Volume {
+	used := make(map[string]bool)
+	for _, service := range c.Services {
+		for _, mount := range service.Volumes {
+			if mount.Type == MountTypeVolume {
+				used[mount.Source] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(c.Volumes))
+	for key := range c.Volumes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var volumes []*Volume
+	for _, key := range keys {
+		if volume := c.Volumes[key]; used[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+	return volumes
+}
+
+// Exists checks if the volume exists.
+func (volume *Volume) Exists(ctx context.Context) (bool, error) {
+	cmd, err := commands.VolumeInspect(volume.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if err := cmd.Exec(ctx); err != nil {
+		return false, nil // If inspect fails, assume the volume doesn't exist
+	}
+
+	return true, nil
+}
+
+// CreateCommand creates a command to create the volume, labelled with the project.
+func (volume *Volume) CreateCommand(ctx context.Context) (*commands.VolumeCreateCommand, error) {
+	if volume.IsExternal() {
+		return nil, fmt.Errorf("volume %q is external and has to be created outside of the project", volume.Name)
+	}
+
+	cmd, err := commands.VolumeCreate(volume.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]string)
+	for k, v := range volume.Labels {
+		labels[k] = v
+	}
+	if volume.Project != "" {
+		labels[LabelProject] = volume.Project
+	}
+	labels[LabelVolume] = volume.Key
+	cmd.SetLabels(labels)
+
+	if len(volume.DriverOpts) > 0 {
+		cmd.SetOptions(volume.DriverOpts)
+	}
+
+	return cmd, nil
+}
+
+// DeleteCommand creates a command to delete the volume.
+func (volume *Volume) DeleteCommand(ctx context.Context) (*commands.VolumeDeleteCommand, error) {
+	if volume.IsExternal() {
+		return nil, fmt.Errorf("volume %q is external and is not managed by the project", volume.Name)
+	}
+
+	return commands.VolumeDelete(volume.Name)
+}
+
+// Up makes sure the volume exists, creating it when it is missing. It reports whether the
+// volume had to be created.
+func (volume *Volume) Up(ctx context.Context) (bool, error) {
+	exists, err := volume.Exists(ctx)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	if volume.IsExternal() {
+		return false, fmt.Errorf("external volume %q does not exist", volume.Name)
+	}
+
+	cmd, err := volume.CreateCommand(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, cmd.Exec(ctx)
+}
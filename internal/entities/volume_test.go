@@ -0,0 +1,150 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveVolumes(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantNames   map[string]string // volume key to name
+		wantSources []string          // the sources of web's mounts
+		wantErr     string
+	}{
+		{
+			name: "named after the project",
+			content: `
+name: shop
+services:
+  web:
+    volumes: ["data:/data"]
+volumes:
+  data: {}`,
+			wantNames:   map[string]string{"data": "shop_data"},
+			wantSources: []string{"shop_data"},
+		},
+		{
+			name: "named explicitly",
+			content: `
+name: shop
+services:
+  web:
+    volumes: ["data:/data"]
+volumes:
+  data:
+    name: shared-data`,
+			wantNames:   map[string]string{"data": "shared-data"},
+			wantSources: []string{"shared-data"},
+		},
+		{
+			name: "external",
+			content: `
+name: shop
+services:
+  web:
+    volumes: ["data:/data"]
+volumes:
+  data:
+    external: true`,
+			wantNames:   map[string]string{"data": "data"},
+			wantSources: []string{"data"},
+		},
+		{
+			name: "bind mounts and tmpfs are left alone",
+			content: `
+name: shop
+services:
+  web:
+    volumes:
+      - /host/logs:/logs
+      - type: tmpfs
+        target: /tmp`,
+			wantSources: []string{"/host/logs", ""},
+		},
+		{
+			name: "undeclared volume",
+			content: `
+name: shop
+services:
+  web:
+    volumes: ["data:/data"]`,
+			wantErr: `service "web" uses undeclared volume "data"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, tt.content)
+
+			err := config.ResolveVolumes()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveVolumes returned %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVolumes returned an error: %v", err)
+			}
+
+			for key, want := range tt.wantNames {
+				if got := config.Volumes[key].Name; got != want {
+					t.Errorf("volume %s name = %q, want %q", key, got, want)
+				}
+			}
+			mounts := config.Services["web"].Volumes
+			if len(mounts) != len(tt.wantSources) {
+				t.Fatalf("mounts = %v, want %d", mounts, len(tt.wantSources))
+			}
+			for i, want := range tt.wantSources {
+				if mounts[i].Source != want {
+					t.Errorf("mount %s source = %q, want %q", mounts[i].Target, mounts[i].Source, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUsedVolumes(t *testing.T) {
+	const content = `
+name: shop
+services:
+  web:
+    volumes: ["data:/data", "./logs:/logs"]
+  debug:
+    profiles: [debug]
+    volumes: ["traces:/traces", "data:/data"]
+volumes:
+  data: {}
+  traces: {}
+  unused: {}`
+
+	tests := []struct {
+		name     string
+		profiles []string
+		want     []string
+	}{
+		{name: "without the profile", want: []string{"shop_data"}},
+		{name: "with the profile", profiles: []string{"debug"}, want: []string{"shop_data", "shop_traces"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(t, content)
+			if err := config.ResolveVolumes(); err != nil {
+				t.Fatalf("ResolveVolumes returned an error: %v", err)
+			}
+			config.ApplyProfiles(tt.profiles)
+
+			var got []string
+			for _, volume := range config.UsedVolumes() {
+				got = append(got, volume.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("used volumes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// LineWriter buffers the output of a single source and writes it to a shared writer one
+// complete line at a time, so that lines from sources writing at the same time never
+// interleave. Every LineWriter sharing a writer must share the same mutex. A LineWriter is
+// safe to use as both the stdout and the stderr of a command. A carriage return also ends a
+// line, so progress which redraws itself is shown as it is made rather than all at the end.
+type LineWriter struct {
+	mu         *sync.Mutex
+	out        io.Writer
+	prefix     string
+	buf        []byte
+	cr         bool             // the last line ended with a carriage return
+	timestamps bool             // each line is prefixed with the time it was received
+	since      time.Time        // lines received before this are dropped
+	now        func() time.Time // tells the time a line is received
+}
+
+func NewLineWriter(out io.Writer, mu *sync.Mutex, prefix string) *LineWriter {
+	return &LineWriter{
+		mu:     mu,
+		out:    out,
+		prefix: prefix,
+		now:    time.Now,
+	}
+}
+
+// SetTimestamps prefixes each line with the time it was received
+func (w *LineWriter) SetTimestamps(timestamps bool) *LineWriter {
+	w.timestamps = timestamps
+	return w
+}
+
+// SetSince drops the lines received before since
+func (w *LineWriter) SetSince(since time.Time) *LineWriter {
+	w.since = since
+	return w
+}
+
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	// the newline of a \r\n split across writes ends the line the carriage return ended
+	if w.cr && len(w.buf) > 0 {
+		if w.buf[0] == '\n' {
+			w.buf = w.buf[1:]
+		}
+		w.cr = false
+	}
+
+	for {
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i]); err != nil {
+			return 0, err
+		}
+
+		end := i + 1
+		if w.buf[i] == '\r' {
+			switch {
+			case end == len(w.buf):
+				w.cr = true
+			case w.buf[end] == '\n':
+				end++
+			}
+		}
+		w.buf = w.buf[end:]
+	}
+	return len(p), nil
+}
+
+// Flush writes any output which was not terminated by a newline.
+func (w *LineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(w.buf)
+	w.buf = nil
+	return err
+}
+
+// writeLine writes a single line to the shared writer. The caller must hold the mutex.
+func (w *LineWriter) writeLine(line []byte) error {
+	received := w.now()
+	if received.Before(w.since) {
+		return nil
+	}
+
+	var b bytes.Buffer
+	b.WriteString(w.prefix)
+	if w.timestamps {
+		b.WriteString(received.UTC().Format(time.RFC3339Nano))
+		b.WriteByte(' ')
+	}
+	b.Write(line)
+	b.WriteByte('\n')
+
+	_, err := w.out.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name:   "whole lines",
+			writes: []string{"one\ntwo\n"},
+			want:   "p | one\np | two\n",
+		},
+		{
+			name:   "lines split across writes",
+			writes: []string{"o", "ne\ntw", "o\n"},
+			want:   "p | one\np | two\n",
+		},
+		{
+			name:   "unterminated output is flushed",
+			writes: []string{"one\ntail"},
+			want:   "p | one\np | tail\n",
+		},
+		{
+			name:   "carriage returns end lines",
+			writes: []string{"10%\r20%\r", "30%\rdone\n"},
+			want:   "p | 10%\np | 20%\np | 30%\np | done\n",
+		},
+		{
+			name:   "windows line endings",
+			writes: []string{"one\r\ntwo\r\n"},
+			want:   "p | one\np | two\n",
+		},
+		{
+			name:   "windows line endings split across writes",
+			writes: []string{"one\r", "\ntwo\r", "", "\n"},
+			want:   "p | one\np | two\n",
+		},
+		{
+			name:   "empty lines are kept",
+			writes: []string{"one\n\ntwo\n"},
+			want:   "p | one\np | \np | two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewLineWriter(&out, &sync.Mutex{}, "p | ")
+			for _, write := range tt.writes {
+				if n, err := w.Write([]byte(write)); err != nil || n != len(write) {
+					t.Fatalf("Write(%q) = %d, %v, want %d, nil", write, n, err, len(write))
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush returned an error: %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineWriterTimestamps(t *testing.T) {
+	received := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		timestamps bool
+		since      time.Time
+		want       string
+	}{
+		{name: "plain", want: "p | one\n"},
+		{name: "timestamps", timestamps: true, want: "p | 2026-10-17T09:30:00Z one\n"},
+		{name: "received since", since: received.Add(-time.Minute), want: "p | one\n"},
+		{name: "received before since", since: received.Add(time.Minute), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := NewLineWriter(&out, &sync.Mutex{}, "p | ").SetTimestamps(tt.timestamps).SetSince(tt.since)
+			w.now = func() time.Time { return received }
+
+			if _, err := w.Write([]byte("one\n")); err != nil {
+				t.Fatalf("Write returned an error: %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineWriterConcurrentWrites(t *testing.T) {
+	var out bytes.Buffer
+	mu := &sync.Mutex{}
+	stdout := NewLineWriter(&out, mu, "a | ")
+	stderr := NewLineWriter(&out, mu, "b | ")
+
+	// a command's stdout and stderr may be the same writer, written from two goroutines
+	writers := []*LineWriter{stdout, stdout, stderr}
+	wg := sync.WaitGroup{}
+	for _, w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				w.Write([]byte("some output\n"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 100*len(writers) {
+		t.Fatalf("wrote %d lines, want %d", len(lines), 100*len(writers))
+	}
+	for _, line := range lines {
+		if line != "a | some output" && line != "b | some output" {
+			t.Errorf("line %q was interleaved with another", line)
+		}
+	}
+}
@@ -11,6 +11,8 @@ const (
 	Inspect = "003"
 	Start   = "004"
 	Build   = "005"
+	Image   = "006"
+	Exec    = "007"
 )
 
 var (
@@ -33,6 +35,12 @@ var (
 	ErrDockerfileNotFound   = New(Build, "001", "Dockerfile not found")
 	ErrBuildContextNotFound = New(Build, "002", "Build context directory not found")
 	ErrBuildFailed          = New(Build, "003", "Build failed")
+
+	// image errors
+	ErrReferenceCannotBeEmpty = New(Image, "001", "Image reference cannot be empty")
+
+	// exec errors
+	ErrCommandCannotBeEmpty = New(Exec, "001", "Command cannot be empty")
 )
 
 var (
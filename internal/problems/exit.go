@@ -0,0 +1,13 @@
+package problems
+
+import "fmt"
+
+// ExitError is returned by commands which run a process on behalf of the user, so that the
+// CLI can exit with the same code as the process.
+type ExitError struct {
+	Code int
+}
+
+func (e ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
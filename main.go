@@ -1,9 +1,19 @@
 package main
 
 import (
+	"errors"
+	"os"
+
 	"github.com/container-compose/cli/cmd"
+	"github.com/container-compose/cli/internal/problems"
 )
 
 func main() {
-	cmd.Execute()
+	if err := cmd.Execute(); err != nil {
+		var exitErr problems.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		os.Exit(1)
+	}
 }